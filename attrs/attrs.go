@@ -9,54 +9,48 @@
 package attrs
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/ruauka/tools-go/rmath"
 )
 
-// float bit size.
-const (
-	bitSize32 = 32
-	bitSize64 = 64
-)
-
-var (
-	errNotStruct           = errors.New("not a struct")
-	errFieldNotInStruct    = errors.New("field not in struct")
-	errUnexportedField     = errors.New("field not exported")
-	errWrongFieldValueType = errors.New("wrong field value type")
-	errNotPointerStruct    = errors.New("struct passed not by pointer")
-	errPointerStruct       = errors.New("struct passed by pointer")
-)
-
 // GetAttr - get struct field value.
 // 'obj': value param, fields can be ptr or value.
-// 'fieldName': value param.
-func GetAttr(obj interface{}, fieldName string) (interface{}, error) {
+// 'fieldName': value param, field name or path like `Customer.Address.City`, `Items[2].Price`, `Meta["region"]`.
+// 'opts': optional, WithTagKey to resolve names by struct tag.
+// Generated AttrGetter is used for field names without opts.
+func GetAttr(obj interface{}, fieldName string, opts ...Option) (interface{}, error) {
 	// to reflect value
 	objValue := reflect.ValueOf(obj)
 	// struct ptr check
 	if objValue.Kind() == reflect.Ptr {
-		return nil, errPointerStruct
+		return nil, objError(opGetAttr, ErrPointerStruct, objValue)
 	}
 	// is struct check
 	if objValue.Kind() != reflect.Struct {
-		return nil, errNotStruct
+		return nil, objError(opGetAttr, ErrNotStruct, objValue)
 	}
-	// get field value
-	field := objValue.FieldByName(fieldName)
-	// is field in struct
-	if !field.IsValid() {
-		return nil, errFieldNotInStruct
+	// generated GetAttr check
+	if len(opts) == 0 && isFieldName(fieldName) && isGenerated(objValue.Type(), genGetter) {
+		value, err := obj.(AttrGetter).GetAttr(fieldName)
+		if err != nil {
+			return nil, opError(opGetAttr, err)
+		}
+
+		return value, nil
 	}
-	// is field exported
-	if !field.CanInterface() {
-		return nil, errUnexportedField
+	// get field value
+	field, err := getField(objValue, fieldName, newOptions(opts))
+	if err != nil {
+		return nil, opError(opGetAttr, err)
 	}
 	// field  ptr check
 	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, nil
+		}
+
 		return field.Elem().Interface(), nil
 	}
 
@@ -64,140 +58,121 @@ func GetAttr(obj interface{}, fieldName string) (interface{}, error) {
 }
 
 // SetAttr - set new value on structure field.
-// 'obj': ptr struct, fields can be ptr or value.
-// 'fieldName', 'newValue': value param.
-func SetAttr(obj, newValue interface{}, fieldName string) error {
-	var (
-		// to reflect value
-		objValue = reflect.ValueOf(obj)
-		field    reflect.Value
-	)
+// 'obj': ptr struct, fields can be ptr or value. Nil ptr fields on the path are allocated.
+// 'fieldName': value param, field name or path like `Customer.Address.City`, `Items[2].Price`, `Meta["region"]`.
+// 'newValue': value param.
+// 'opts': optional, WithConvert to convert newValue to field type, WithTagKey to resolve names by struct tag.
+// Generated AttrSetter is used for field names without opts.
+func SetAttr(obj, newValue interface{}, fieldName string, opts ...Option) error {
+	// to reflect value
+	objValue := reflect.ValueOf(obj)
 	// struct ptr check
 	if objValue.Kind() != reflect.Ptr {
-		return errNotPointerStruct
+		return objError(opSetAttr, ErrNotPointerStruct, objValue)
 	}
 	// is struct check
 	if objValue.Elem().Kind() != reflect.Struct {
-		return errNotStruct
-	}
-	// get field value
-	objValue = objValue.Elem().FieldByName(fieldName)
-	// field  ptr check
-	if objValue.Kind() == reflect.Ptr {
-		field = objValue.Elem()
-	} else {
-		field = objValue
+		return objError(opSetAttr, ErrNotStruct, objValue)
 	}
-	// is field in struct
-	if !field.IsValid() {
-		return errFieldNotInStruct
-	}
-	// types check
-	if field.Type() != reflect.TypeOf(newValue) {
-		return errWrongFieldValueType
-	}
-	// is field exported
-	if !field.CanSet() {
-		return errUnexportedField
+	// generated SetAttr check
+	if len(opts) == 0 && isFieldName(fieldName) && isGenerated(objValue.Type(), genSetter) {
+		if err := obj.(AttrSetter).SetAttr(fieldName, newValue); err != nil {
+			return opError(opSetAttr, err)
+		}
+
+		return nil
 	}
 	// set value
-	field.Set(reflect.ValueOf(newValue))
+	if err := setField(objValue.Elem(), fieldName, reflect.ValueOf(newValue), newOptions(opts)); err != nil {
+		return opError(opSetAttr, err)
+	}
 
 	return nil
 }
 
 // SetStructAttrs - updates current structure fields with the values of the new structure fields.
+// Fields are matched by names, so structs can be of different types.
 // 'curObj': ptr struct, fields can be ptr or value.
-// 'newObj': value struct, fields can be ptr or value.
-func SetStructAttrs(curObj, newObj interface{}) error {
-	// to reflect value
-	objValue := reflect.ValueOf(newObj)
+// 'newObj': value struct, fields can be ptr or value. Nil ptr fields are skipped.
+// 'opts': optional, same as for SetAttr. With WithTagKey fields are matched by tag names.
+// Merge policies: WithSkipZero, WithKeepExisting, WithAppendSlices, WithMergeMaps, WithDeepMerge,
+// WithIgnoreMissing, WithFields, WithoutFields.
+func SetStructAttrs(curObj, newObj interface{}, opts ...Option) error {
+	var (
+		// to reflect value
+		objValue = reflect.ValueOf(newObj)
+		curValue = reflect.ValueOf(curObj)
+		o        = newOptions(opts)
+	)
 	// is struct check
 	if objValue.Kind() != reflect.Struct {
-		return errNotStruct
+		return objError(opSetStructAttrs, ErrNotStruct, objValue)
+	}
+	// struct ptr check
+	if curValue.Kind() != reflect.Ptr {
+		return objError(opSetStructAttrs, ErrNotPointerStruct, curValue)
+	}
+	// is struct check
+	if curValue.Elem().Kind() != reflect.Struct {
+		return objError(opSetStructAttrs, ErrNotStruct, curValue)
 	}
+	// get value from ptr
+	curValue = curValue.Elem()
 
-	for i := 0; i < objValue.NumField(); i++ {
-		// get field reflect value
-		field := objValue.Field(i)
-		// is ptr && is ptr nil check
-		if field.Kind() == reflect.Ptr && field.IsNil() {
-			continue
-		}
-		// get newObj field name
-		fieldName := objValue.Type().Field(i).Name
-		// get newObj field value
-		fieldValue, err := GetAttr(newObj, fieldName)
-		if err != nil {
-			return fmt.Errorf("err in GetAttr: %w", err)
-		}
-		// get curObj field value
-		if err := SetAttr(curObj, fieldValue, fieldName); err != nil {
-			return fmt.Errorf("err in SetAttr: %w", err)
-		}
+	// set fields
+	if err := mergeStruct(curValue, objValue, "", o); err != nil {
+		return opError(opSetStructAttrs, err)
 	}
 
 	return nil
 }
 
-// iterRound - round any float. Private func.
-func iterRound(field reflect.Value, precision int, bitSize int) {
-	for j := 0; j < field.Len(); j++ {
-		field := field.Index(j)
-		// bitSize check
-		if bitSize == bitSize64 {
-			field.Set(reflect.ValueOf(rmath.Round(field.Float(), precision)))
-			continue
-		}
-
-		field.Set(reflect.ValueOf(float32(rmath.Round(field.Float(), precision))))
-	}
-}
-
-// RoundStructFloatFields - round up float struct fields to certain precision
-// Constraint: simple floats, array and slice.
-// 'obj': ptr struct, fields can be value, not ptr.
-// 'precision': round to.
-func RoundStructFloatFields(obj interface{}, precision int) error {
+// RoundStructFloatFields - round up float struct fields to certain precision.
+// Walks recursively: nested structs, ptrs, interfaces, slices, arrays and maps of floats and structs.
+// Unexported fields and nil ptrs are skipped.
+// Field tag `round:"2,half_even"` sets precision and mode of the field and its nested fields,
+// `round:"-"` excludes it. Modes: half_up, half_even, ceil, floor, trunc, py, precision of py is 0..13.
+// 'obj': ptr struct.
+// 'precision': round to, for fields without precision in tag.
+// 'opts': optional, WithRoundMode for fields without mode in tag, with WithTagKey fields with tag "-" are skipped.
+// Generated FloatRounder is used without opts.
+func RoundStructFloatFields(obj interface{}, precision int, opts ...Option) error {
 	objValue := reflect.ValueOf(obj)
 	// struct ptr check
 	if objValue.Kind() != reflect.Ptr {
-		return errNotPointerStruct
+		return objError(opRoundStructFloatFields, ErrNotPointerStruct, objValue)
 	}
 	// is struct check
 	if objValue.Elem().Kind() != reflect.Struct {
-		return errNotStruct
+		return objError(opRoundStructFloatFields, ErrNotStruct, objValue)
 	}
-	// get value from ptr
-	objValue = objValue.Elem()
-
-	for i := 0; i < objValue.NumField(); i++ {
-		field := objValue.Field(i)
-		// is field exported
-		if !field.CanSet() {
-			return errUnexportedField
+	// generated RoundFloatFields check
+	if len(opts) == 0 && isGenerated(objValue.Type(), genRounder) {
+		if err := obj.(FloatRounder).RoundFloatFields(precision); err != nil {
+			return opError(opRoundStructFloatFields, err)
 		}
-		// float types check
-		switch field.Kind() {
-		// simple float
-		case reflect.Float64, reflect.Float32:
-			if field.Kind() == reflect.Float64 {
-				field.Set(reflect.ValueOf(rmath.Round(field.Float(), precision)))
-				break
-			}
-			field.Set(reflect.ValueOf(float32(rmath.Round(field.Float(), precision))))
-		// array and slice float
-		case reflect.Array, reflect.Slice:
-			if field.Len() == 0 {
-				break
-			}
-			if field.Index(0).Kind() == reflect.Float64 {
-				iterRound(field, precision, bitSize64)
-				break
-			}
-			iterRound(field, precision, bitSize32)
-		default:
+
+		return nil
+	}
+
+	o := newOptions(opts)
+	spec := roundSpec{precision: precision, round: rmath.Round[float64]}
+	// default mode check
+	if o.roundMode != "" {
+		round, ok := roundFuncs[o.roundMode]
+		if !ok {
+			return &AttrError{Op: opRoundStructFloatFields, Err: fmt.Errorf("%w: round mode %q", ErrInvalidOption, o.roundMode)}
 		}
+		spec.round, spec.mode = round, o.roundMode
+	}
+	// precision of mode check
+	if !spec.valid() {
+		return &AttrError{Op: opRoundStructFloatFields, Err: precisionError(ErrInvalidOption, spec)}
+	}
+
+	r := &rounder{o: o}
+	if err := r.round(objValue, spec); err != nil {
+		return opError(opRoundStructFloatFields, err)
 	}
 
 	return nil
@@ -39,28 +39,28 @@ func TestGetAttr(t *testing.T) {
 			obj:         &struct{ Username string }{Username: name},
 			fieldName:   "Username",
 			expected:    nil,
-			expectedErr: errPointerStruct,
+			expectedErr: ErrPointerStruct,
 			testName:    "OK. Ptr struct",
 		},
 		{
 			obj:         "not struct arg",
 			fieldName:   "Username",
 			expected:    nil,
-			expectedErr: errNotStruct,
+			expectedErr: ErrNotStruct,
 			testName:    "ERR. Arg not struct",
 		},
 		{
 			obj:         struct{ Username string }{Username: name},
 			fieldName:   "not in struct field",
 			expected:    nil,
-			expectedErr: errFieldNotInStruct,
+			expectedErr: ErrFieldNotInStruct,
 			testName:    "ERR. Field not struct",
 		},
 		{
 			obj:         struct{ username string }{username: name},
 			fieldName:   "username",
 			expected:    nil,
-			expectedErr: errUnexportedField,
+			expectedErr: ErrUnexportedField,
 			testName:    "ERR. Unexported field",
 		},
 	}
@@ -135,35 +135,35 @@ func TestSetAttr(t *testing.T) {
 			obj:         struct{ Username string }{Username: curValue},
 			fieldName:   fieldUsername,
 			newValue:    newValue,
-			expectedErr: errNotPointerStruct,
+			expectedErr: ErrNotPointerStruct,
 			testName:    "ERR. Struct passed not by pointer",
 		},
 		{
 			obj:         &newValue,
 			fieldName:   fieldUsername,
 			newValue:    newValue,
-			expectedErr: errNotStruct,
+			expectedErr: ErrNotStruct,
 			testName:    "ERR. Not struct arg",
 		},
 		{
 			obj:         &struct{ Username string }{Username: curValue},
 			fieldName:   "Field not in struct",
 			newValue:    newValue,
-			expectedErr: errFieldNotInStruct,
+			expectedErr: ErrFieldNotInStruct,
 			testName:    "ERR. Field not in struct",
 		},
 		{
 			obj:         &struct{ Username int }{Username: 0},
 			fieldName:   fieldUsername,
 			newValue:    newValue,
-			expectedErr: errWrongFieldValueType,
+			expectedErr: ErrWrongFieldValueType,
 			testName:    "ERR. Wrong field value type",
 		},
 		{
 			obj:         &struct{ username string }{username: curValue},
 			fieldName:   strings.ToLower(fieldUsername),
 			newValue:    newValue,
-			expectedErr: errUnexportedField,
+			expectedErr: ErrUnexportedField,
 			testName:    "ERR. Unexported field",
 		},
 	}
@@ -364,7 +364,7 @@ func TestSetStructAttrs(t *testing.T) {
 				Married:  curMarried,
 				Friends:  curFriends,
 			},
-			expectedErr: errUnexportedField,
+			expectedErr: ErrUnexportedField,
 			testName:    "ERR. Value fields. Err in GetAttr, field not exported",
 		},
 		{
@@ -385,7 +385,7 @@ func TestSetStructAttrs(t *testing.T) {
 				Married:  curMarried,
 				Friends:  curFriends,
 			},
-			expectedErr: errWrongFieldValueType,
+			expectedErr: ErrWrongFieldValueType,
 			testName:    "ERR. Value fields. Err in SetAttr, Wrong field value type",
 		},
 		{
@@ -402,7 +402,7 @@ func TestSetStructAttrs(t *testing.T) {
 				Married:  curMarried,
 				Friends:  curFriends,
 			},
-			expectedErr: errNotStruct,
+			expectedErr: ErrNotStruct,
 			testName:    "ERR. Value fields. Arg not struct",
 		},
 	}
@@ -522,22 +522,22 @@ func TestRoundStructFloatFields(t *testing.T) {
 			obj:         foo,
 			precision:   3,
 			expected:    foo,
-			expectedErr: errNotPointerStruct,
+			expectedErr: ErrNotPointerStruct,
 			testName:    "ERR. Struct passed not by pointer",
 		},
 		{
 			obj:         &notStruct,
 			precision:   3,
 			expected:    &notStruct,
-			expectedErr: errNotStruct,
+			expectedErr: ErrNotStruct,
 			testName:    "ERR. Arg not struct",
 		},
 		{
-			obj:         &struct{ field1 float64 }{field1: 0},
+			obj:         &struct{ field1 float64 }{field1: 1.1111},
 			precision:   3,
-			expected:    &struct{ field1 float64 }{field1: 0},
-			expectedErr: errUnexportedField,
-			testName:    "ERR. Unexported field",
+			expected:    &struct{ field1 float64 }{field1: 1.1111},
+			expectedErr: nil,
+			testName:    "OK. Unexported field skipped",
 		},
 		{
 			obj:         &struct{ Field1 []float64 }{Field1: nil},
@@ -0,0 +1,160 @@
+package attrs
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// fieldInfo - cached metadata of struct field.
+type fieldInfo struct {
+	name     string            // Go name or tag name
+	index    []int             // index sequence for reflect.Value.FieldByIndex
+	typ      reflect.Type      // field type
+	kind     reflect.Kind      // field kind
+	tag      reflect.StructTag // field tag
+	exported bool              // field is exported
+	viaPtr   bool              // index sequence goes through embedded pointer
+}
+
+// typeInfo - cached metadata of struct type.
+type typeInfo struct {
+	fields       []fieldInfo               // top-level fields in struct order
+	byName       map[string]*fieldInfo     // visible fields by Go name, including promoted ones
+	tags         sync.Map                  // tag key to *tagIndex
+	allExported  bool                      // struct has no unexported fields
+	clone        atomic.Pointer[cloneInfo] // Clone metadata, rebuilt after RegisterCloner
+	generated    generatedSet              // generated interfaces of struct
+	ptrGenerated generatedSet              // generated interfaces of ptr struct
+}
+
+// typeInfos - cache of typeInfo per struct type.
+var typeInfos sync.Map
+
+// getTypeInfo - cached typeInfo of struct type t.
+func getTypeInfo(t reflect.Type) *typeInfo {
+	if info, ok := typeInfos.Load(t); ok {
+		return info.(*typeInfo)
+	}
+
+	info, _ := typeInfos.LoadOrStore(t, buildTypeInfo(t))
+
+	return info.(*typeInfo)
+}
+
+// buildTypeInfo - collect metadata of struct type t.
+func buildTypeInfo(t reflect.Type) *typeInfo {
+	info := &typeInfo{
+		fields:       make([]fieldInfo, t.NumField()),
+		allExported:  true,
+		generated:    generatedMethods(t),
+		ptrGenerated: generatedMethods(reflect.PointerTo(t)),
+	}
+
+	for i := range info.fields {
+		info.fields[i] = newFieldInfo(t, t.Field(i).Name, []int{i})
+		info.allExported = info.allExported && info.fields[i].exported
+	}
+	// reflect.VisibleFields drops hidden and ambiguous fields like FieldByName
+	visible := reflect.VisibleFields(t)
+	info.byName = make(map[string]*fieldInfo, len(visible))
+
+	for _, sf := range visible {
+		if len(sf.Index) == 1 {
+			info.byName[sf.Name] = &info.fields[sf.Index[0]]
+			continue
+		}
+
+		f := newFieldInfo(t, sf.Name, sf.Index)
+		info.byName[sf.Name] = &f
+	}
+
+	return info
+}
+
+// newFieldInfo - metadata of struct type t field by index sequence.
+func newFieldInfo(t reflect.Type, name string, index []int) fieldInfo {
+	f := fieldInfo{name: name, index: index}
+
+	for i, x := range index {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			f.viaPtr = f.viaPtr || i > 0
+		}
+
+		sf := t.Field(x)
+		f.typ = sf.Type
+		f.tag = sf.Tag
+		f.exported = sf.IsExported()
+		t = sf.Type
+	}
+
+	f.kind = f.typ.Kind()
+
+	return f
+}
+
+// tagIndex - cached tagIndex of struct type by tag key.
+func (info *typeInfo) tagIndex(t reflect.Type, key string) *tagIndex {
+	if idx, ok := info.tags.Load(key); ok {
+		return idx.(*tagIndex)
+	}
+
+	idx, _ := info.tags.LoadOrStore(key, buildTagIndex(t, key))
+
+	return idx.(*tagIndex)
+}
+
+// structFields - fields of struct type t: top-level fields by Go names, or by tag names if tag key set.
+func structFields(t reflect.Type, o *options) []fieldInfo {
+	info := getTypeInfo(t)
+	if o.tagKey != "" {
+		return info.tagIndex(t, o.tagKey).fields
+	}
+
+	return info.fields
+}
+
+// lookupField - struct type t field by Go name or tag name.
+func lookupField(t reflect.Type, name string, o *options) (*fieldInfo, bool) {
+	info := getTypeInfo(t)
+	if o.tagKey != "" {
+		f, ok := info.tagIndex(t, o.tagKey).byName[name]
+		return f, ok
+	}
+
+	f, ok := info.byName[name]
+
+	return f, ok
+}
+
+// fieldByInfo - struct value field. Embedded nil pointers are allocated if 'alloc', else ErrNilPointer.
+func fieldByInfo(v reflect.Value, f *fieldInfo, alloc bool) (reflect.Value, error) {
+	// fast path: no embedded pointers
+	if !f.viaPtr {
+		if len(f.index) == 1 {
+			return v.Field(f.index[0]), nil
+		}
+
+		return v.FieldByIndex(f.index), nil
+	}
+
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, ErrNilPointer
+				}
+
+				if !v.CanSet() {
+					return reflect.Value{}, ErrUnexportedField
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,259 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type CacheBase struct {
+	ID int
+}
+
+type cacheInner struct {
+	Code string
+}
+
+type cacheRecord struct {
+	*CacheBase
+	cacheInner
+	Name    string
+	Amount  float64
+	Rate    *float64
+	Tags    []string
+	private int
+}
+
+func TestGetTypeInfo(t *testing.T) {
+	typ := reflect.TypeOf(cacheRecord{})
+
+	testCases := []struct {
+		name     string
+		expected fieldInfo
+		testName string
+	}{
+		{
+			name:     "Name",
+			expected: fieldInfo{name: "Name", index: []int{2}, typ: reflect.TypeOf(""), kind: reflect.String, exported: true},
+			testName: "OK. Top-level field",
+		},
+		{
+			name:     "Rate",
+			expected: fieldInfo{name: "Rate", index: []int{4}, typ: reflect.TypeOf((*float64)(nil)), kind: reflect.Ptr, exported: true},
+			testName: "OK. Ptr field",
+		},
+		{
+			name:     "ID",
+			expected: fieldInfo{name: "ID", index: []int{0, 0}, typ: reflect.TypeOf(0), kind: reflect.Int, exported: true, viaPtr: true},
+			testName: "OK. Promoted field via embedded ptr",
+		},
+		{
+			name:     "Code",
+			expected: fieldInfo{name: "Code", index: []int{1, 0}, typ: reflect.TypeOf(""), kind: reflect.String, exported: true},
+			testName: "OK. Promoted field of unexported embedded struct",
+		},
+		{
+			name:     "private",
+			expected: fieldInfo{name: "private", index: []int{6}, typ: reflect.TypeOf(0), kind: reflect.Int},
+			testName: "OK. Unexported field",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("getTypeInfo")
+			t.Description("Check func `getTypeInfo`")
+			t.WithParameters(
+				allure.NewParameter("name", testCase.name),
+			)
+
+			info := getTypeInfo(typ)
+			t.Assert().Same(info, getTypeInfo(typ), "Check cache")
+
+			actual, ok := info.byName[testCase.name]
+			t.Require().True(ok, "Check field found")
+			t.Assert().Equal(testCase.expected, *actual, "Check getTypeInfo")
+		})
+	}
+}
+
+func TestSetAttrEmbeddedPtr(t *testing.T) {
+	runner.Run(t, "OK. Nil embedded ptr", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetAttr")
+		t.Description("Check funcs `GetAttr` and `SetAttr` with promoted field via nil embedded ptr")
+
+		_, err := GetAttr(cacheRecord{}, "ID")
+		t.Assert().ErrorIs(err, ErrNilPointer)
+
+		record := &cacheRecord{}
+		t.Require().NoError(SetAttr(record, 7, "ID"))
+		t.Assert().Equal(&CacheBase{ID: 7}, record.CacheBase)
+
+		err = SetStructAttrs(record, cacheRecord{Name: "name"})
+		t.Require().ErrorIs(err, ErrUnexportedField)
+	})
+}
+
+// getAttrFieldByName - GetAttr without metadata cache, baseline for benchmarks.
+func getAttrFieldByName(obj interface{}, fieldName string) (interface{}, error) {
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	field := objValue.FieldByName(fieldName)
+	if !field.IsValid() {
+		return nil, ErrFieldNotInStruct
+	}
+
+	if !field.CanInterface() {
+		return nil, ErrUnexportedField
+	}
+
+	if field.Kind() == reflect.Ptr {
+		return field.Elem().Interface(), nil
+	}
+
+	return field.Interface(), nil
+}
+
+// setAttrFieldByName - SetAttr without metadata cache, baseline for benchmarks.
+func setAttrFieldByName(obj, newValue interface{}, fieldName string) error {
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() != reflect.Ptr || objValue.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerStruct
+	}
+
+	field := objValue.Elem().FieldByName(fieldName)
+	if field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	if !field.IsValid() {
+		return ErrFieldNotInStruct
+	}
+
+	if field.Type() != reflect.TypeOf(newValue) {
+		return ErrWrongFieldValueType
+	}
+
+	if !field.CanSet() {
+		return ErrUnexportedField
+	}
+
+	field.Set(reflect.ValueOf(newValue))
+
+	return nil
+}
+
+// setStructAttrsFieldByName - SetStructAttrs by GetAttr and SetAttr without metadata cache, baseline for benchmarks.
+func setStructAttrsFieldByName(curObj, newObj interface{}) error {
+	objValue := reflect.ValueOf(newObj)
+	if objValue.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	for i := 0; i < objValue.NumField(); i++ {
+		field := objValue.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			continue
+		}
+
+		fieldName := objValue.Type().Field(i).Name
+
+		fieldValue, err := getAttrFieldByName(newObj, fieldName)
+		if err != nil {
+			return err
+		}
+
+		if err := setAttrFieldByName(curObj, fieldValue, fieldName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// benchRecord - wide struct, the last field is the slowest for FieldByName.
+type benchRecord struct {
+	Field1, Field2, Field3, Field4, Field5, Field6, Field7, Field8 string
+	Field9, Field10, Field11, Field12, Field13, Field14, Field15   int
+	Amount                                                         float64
+}
+
+func BenchmarkGetAttr(b *testing.B) {
+	record := benchRecord{Amount: 1.5}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := GetAttr(record, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("FieldByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := getAttrFieldByName(record, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSetAttr(b *testing.B) {
+	record := &benchRecord{}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := SetAttr(record, 1.5, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("FieldByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := setAttrFieldByName(record, 1.5, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSetStructAttrs(b *testing.B) {
+	record := &benchRecord{}
+	newRecord := benchRecord{Field1: "field1", Field9: 9, Amount: 1.5}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := SetStructAttrs(record, newRecord); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("FieldByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := setStructAttrsFieldByName(record, newRecord); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package attrs
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+var (
+	// cloners - custom cloners by type, registered by RegisterCloner.
+	cloners sync.Map
+	// clonersGen - RegisterCloner calls count, cached cloneInfo of older generation is stale.
+	clonersGen atomic.Uint64
+)
+
+// RegisterCloner - use fn to clone values of type T by Clone, also for nested values.
+// For types with unexported state like sync primitives or handles.
+func RegisterCloner[T any](fn func(T) T) {
+	cloners.Store(reflect.TypeFor[T](), func(v reflect.Value) reflect.Value {
+		out := fn(v.Interface().(T))
+		return reflect.ValueOf(&out).Elem()
+	})
+	clonersGen.Add(1)
+}
+
+// WithUnexported - Clone deep-copies unexported fields via unsafe, by default they are copied shallow.
+func WithUnexported() Option {
+	return func(o *options) {
+		o.unexported = true
+	}
+}
+
+// Clone - deep copy of structs, ptrs, slices, arrays, maps and interfaces. Pointer aliasing and cycles
+// are preserved: the same ptr or map is cloned once. Chans and funcs are copied shallow.
+// 'v': value to clone.
+// 'opts': optional, WithUnexported to deep-copy unexported fields.
+func Clone[T any](v T, opts ...Option) T {
+	var (
+		c   = &cloner{o: newOptions(opts)}
+		out T
+	)
+
+	reflect.ValueOf(&out).Elem().Set(c.clone(reflect.ValueOf(&v).Elem()))
+
+	return out
+}
+
+// sliceKey - slice identity: the same backing array, length and type.
+type sliceKey struct {
+	ptrKey
+	length int
+}
+
+// cloner - deep copies values, remembers cloned ptrs, maps and slices.
+type cloner struct {
+	o      *options
+	ptrs   map[ptrKey]reflect.Value
+	slices map[sliceKey]reflect.Value
+}
+
+// remember - register clone of ptr, map or slice identity.
+func (c *cloner) remember(key ptrKey, out reflect.Value) {
+	if c.ptrs == nil {
+		c.ptrs = make(map[ptrKey]reflect.Value)
+	}
+
+	c.ptrs[key] = out
+}
+
+// clone - deep copy of value.
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	t := v.Type()
+	// custom cloner
+	if fn, ok := cloners.Load(t); ok {
+		return fn.(func(reflect.Value) reflect.Value)(v)
+	}
+
+	if !holdsRefs(t) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		// aliasing and cycle check
+		key := ptrKey{addr: v.Pointer(), typ: t}
+		if out, ok := c.ptrs[key]; ok {
+			return out
+		}
+
+		out := reflect.New(t.Elem())
+		c.remember(key, out)
+		out.Elem().Set(c.clone(v.Elem()))
+
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		// aliasing and cycle check
+		key := ptrKey{addr: v.Pointer(), typ: t}
+		if out, ok := c.ptrs[key]; ok {
+			return out
+		}
+
+		out := reflect.MakeMapWithSize(t, v.Len())
+		c.remember(key, out)
+
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		// aliasing check
+		key := sliceKey{ptrKey: ptrKey{addr: v.Pointer(), typ: t}, length: v.Len()}
+		if out, ok := c.slices[key]; ok {
+			return out
+		}
+
+		out := reflect.MakeSlice(t, v.Len(), v.Cap())
+		if c.slices == nil {
+			c.slices = make(map[sliceKey]reflect.Value)
+		}
+		c.slices[key] = out
+
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.New(t).Elem()
+		out.Set(c.clone(v.Elem()))
+
+		return out
+	case reflect.Struct:
+		return c.cloneStruct(v)
+	default:
+		return v
+	}
+}
+
+// cloneStruct - shallow copy of struct, then deep copy of its fields.
+func (c *cloner) cloneStruct(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+
+	info := getTypeInfo(v.Type())
+	for _, i := range info.cloneInfo(v.Type()).fields {
+		f := &info.fields[i]
+
+		field := out.Field(f.index[0])
+		if !f.exported {
+			if !c.o.unexported {
+				continue
+			}
+			// unexported field is readable and settable via unsafe
+			field = reflect.NewAt(f.typ, unsafe.Pointer(field.UnsafeAddr())).Elem()
+		}
+
+		field.Set(c.clone(field))
+	}
+
+	return out
+}
+
+// cloneInfo - cached Clone metadata of struct type.
+type cloneInfo struct {
+	gen    uint64 // clonersGen at build
+	refs   bool   // struct can hold references or has fields with custom cloners
+	fields []int  // indexes of typeInfo fields to deep copy
+}
+
+// cloneInfo - cached cloneInfo of struct type t, rebuilt if cloners changed.
+func (info *typeInfo) cloneInfo(t reflect.Type) *cloneInfo {
+	gen := clonersGen.Load()
+	if ci := info.clone.Load(); ci != nil && ci.gen == gen {
+		return ci
+	}
+
+	ci := &cloneInfo{gen: gen}
+	for i := range info.fields {
+		if needsClone(info.fields[i].typ) {
+			ci.fields = append(ci.fields, i)
+		}
+	}
+
+	ci.refs = len(ci.fields) > 0
+	info.clone.Store(ci)
+
+	return ci
+}
+
+// needsClone - type can hold references (ptrs, slices, maps, interfaces) or has custom cloner.
+func needsClone(t reflect.Type) bool {
+	if _, ok := cloners.Load(t); ok {
+		return true
+	}
+
+	return holdsRefs(t)
+}
+
+// holdsRefs - type can hold references or nested values with custom cloners, cached for structs.
+func holdsRefs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return true
+	case reflect.Array:
+		return needsClone(t.Elem())
+	case reflect.Struct:
+		return getTypeInfo(t).cloneInfo(t).refs
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type cloneNode struct {
+	Name     string
+	Next     *cloneNode
+	Children []*cloneNode
+}
+
+type cloneItem struct {
+	Name  string
+	Price float64
+}
+
+type cloneOrder struct {
+	ID      int
+	Items   []cloneItem
+	Main    *cloneItem
+	Alias   *cloneItem
+	Meta    map[string][]string
+	Any     interface{}
+	Grid    [2][]int
+	Created time.Time
+	private *cloneItem
+}
+
+type cloneCounter struct {
+	mu    sync.Mutex
+	Count int
+}
+
+func TestClone(t *testing.T) {
+	runner.Run(t, "OK. Deep copy", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Clone")
+		t.Description("Check func `Clone` deep copies ptrs, slices, maps, arrays and interfaces")
+
+		main := &cloneItem{Name: "main"}
+		order := &cloneOrder{
+			ID:      1,
+			Items:   []cloneItem{{Name: "a", Price: 1}},
+			Main:    main,
+			Alias:   main,
+			Meta:    map[string][]string{"k": {"v"}},
+			Any:     &cloneItem{Name: "any"},
+			Grid:    [2][]int{{1}, {2}},
+			Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			private: main,
+		}
+
+		clone := Clone(order)
+		t.Require().Equal(order, clone, "Check equal values")
+
+		clone.Items[0].Name = "b"
+		clone.Main.Name = "new_main"
+		clone.Meta["k"][0] = "new_v"
+		clone.Any.(*cloneItem).Name = "new_any"
+		clone.Grid[0][0] = 10
+
+		t.Assert().Equal("a", order.Items[0].Name)
+		t.Assert().Equal("main", order.Main.Name)
+		t.Assert().Equal("v", order.Meta["k"][0])
+		t.Assert().Equal("any", order.Any.(*cloneItem).Name)
+		t.Assert().Equal(1, order.Grid[0][0])
+		t.Assert().Same(clone.Main, clone.Alias, "Check aliasing preserved")
+		t.Assert().Same(order.private, clone.private, "Check unexported field copied shallow")
+	})
+
+	runner.Run(t, "OK. Unexported fields", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Clone")
+		t.Description("Check func `Clone` with `WithUnexported` option")
+
+		main := &cloneItem{Name: "main"}
+		order := cloneOrder{Main: main, private: main}
+
+		clone := Clone(order, WithUnexported())
+		t.Assert().NotSame(order.private, clone.private, "Check unexported field deep copied")
+		t.Assert().Same(clone.Main, clone.private, "Check aliasing preserved")
+		t.Assert().Equal(*order.private, *clone.private)
+	})
+
+	runner.Run(t, "OK. Cycles", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Clone")
+		t.Description("Check func `Clone` preserves cycles")
+
+		root := &cloneNode{Name: "root"}
+		child := &cloneNode{Name: "child", Next: root}
+		root.Next = root
+		root.Children = []*cloneNode{child, child}
+
+		clone := Clone(root)
+		t.Assert().NotSame(root, clone)
+		t.Assert().Same(clone, clone.Next)
+		t.Assert().Same(clone, clone.Children[0].Next)
+		t.Assert().Same(clone.Children[0], clone.Children[1])
+		t.Assert().NotSame(child, clone.Children[0])
+		t.Assert().Equal("child", clone.Children[0].Name)
+	})
+
+	runner.Run(t, "OK. Nil and interface values", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Clone")
+		t.Description("Check func `Clone` on nil values")
+
+		t.Assert().Nil(Clone[*cloneNode](nil))
+		t.Assert().Nil(Clone[fmt.Stringer](nil))
+		t.Assert().Nil(Clone[map[string]int](nil))
+		t.Assert().Equal(1, Clone(1))
+
+		var value interface{} = []int{1}
+		clone := Clone(value)
+		clone.([]int)[0] = 2
+		t.Assert().Equal([]int{1}, value)
+	})
+}
+
+func TestRegisterCloner(t *testing.T) {
+	runner.Run(t, "OK. Custom cloner", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RegisterCloner")
+		t.Description("Check func `RegisterCloner` is used for nested values")
+
+		RegisterCloner(func(c *cloneCounter) *cloneCounter {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			return &cloneCounter{Count: c.Count + 100}
+		})
+		defer func() {
+			cloners.Delete(reflect.TypeFor[*cloneCounter]())
+			clonersGen.Add(1)
+		}()
+
+		type Stats struct {
+			Counters map[string]*cloneCounter
+		}
+
+		stats := Stats{Counters: map[string]*cloneCounter{"a": {Count: 1}}}
+
+		clone := Clone(stats)
+		t.Assert().Equal(101, clone.Counters["a"].Count)
+		t.Assert().Equal(1, stats.Counters["a"].Count)
+	})
+
+	runner.Run(t, "OK. Cloner of cached type", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RegisterCloner")
+		t.Description("Check func `RegisterCloner` is used for struct types cloned before")
+
+		type Money struct {
+			Amount int
+		}
+
+		type Price struct {
+			Value Money
+		}
+
+		price := Price{Value: Money{Amount: 1}}
+		t.Assert().Equal(price, Clone(price))
+
+		RegisterCloner(func(m Money) Money {
+			return Money{Amount: m.Amount * 10}
+		})
+		defer func() {
+			cloners.Delete(reflect.TypeFor[Money]())
+			clonersGen.Add(1)
+		}()
+
+		t.Assert().Equal(Price{Value: Money{Amount: 10}}, Clone(price))
+	})
+}
+
+func ExampleClone() {
+	type Item struct {
+		Name string
+	}
+
+	type Order struct {
+		Items []Item
+		Main  *Item
+		Alias *Item
+	}
+
+	main := &Item{Name: "main"}
+	order := Order{Items: []Item{{Name: "a"}}, Main: main, Alias: main}
+
+	clone := Clone(order)
+	clone.Items[0].Name = "b"
+	clone.Main.Name = "new_main"
+
+	fmt.Println(order.Items[0].Name, order.Main.Name)
+	fmt.Println(clone.Items[0].Name, clone.Main.Name, clone.Alias.Name)
+	// Output:
+	// a main
+	// b new_main new_main
+}
@@ -0,0 +1,365 @@
+package attrs
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruauka/tools-go/conv"
+	"github.com/ruauka/tools-go/rmath"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	// time layouts for string to time.Time conversion.
+	timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+	// text values are converted to field types like WithConvert.
+	textOptions = &options{convert: true}
+)
+
+// convertValue - convert value to type t. Returns value of type t.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	// nil check
+	if !v.IsValid() {
+		return reflect.Value{}, typeError(ErrWrongFieldValueType, t, nil)
+	}
+	// same type
+	if v.Type() == t {
+		return v, nil
+	}
+	// interface field
+	if t.Kind() == reflect.Interface && v.Type().AssignableTo(t) {
+		return v.Convert(t), nil
+	}
+	// value ptr check
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return convertValue(v.Elem(), t)
+	}
+
+	out, err := convertKind(v, t)
+	if err != nil {
+		return reflect.Value{}, typeError(err, t, v.Type())
+	}
+
+	return out, nil
+}
+
+// convertKind - convert value to type t by kinds of both.
+func convertKind(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	// special types, parsed from string
+	if v.Kind() == reflect.String {
+		switch {
+		case t == timeType:
+			return parseTime(v.String())
+		case t == durationType:
+			d, err := time.ParseDuration(v.String())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%w: %v", ErrConversion, err)
+			}
+
+			return reflect.ValueOf(d), nil
+		case reflect.PointerTo(t).Implements(textUnmarshalerType):
+			out := reflect.New(t)
+			if err := out.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v.String())); err != nil {
+				return reflect.Value{}, fmt.Errorf("%w: %v", ErrConversion, err)
+			}
+
+			return out.Elem(), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return toInt(v, t)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return toUint(v, t)
+	case reflect.Float32, reflect.Float64:
+		return toFloat(v, t)
+	case reflect.Bool:
+		if v.Kind() == reflect.String {
+			b, err := strconv.ParseBool(v.String())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%w: %v", ErrConversion, err)
+			}
+
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+	default:
+	}
+	// named types with the same kind
+	if v.Kind() == t.Kind() && v.Type().ConvertibleTo(t) {
+		return v.Convert(t), nil
+	}
+
+	return reflect.Value{}, ErrWrongFieldValueType
+}
+
+// toInt - convert number or string to signed int type t with overflow check.
+func toInt(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	var n int64
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return reflect.Value{}, ErrValueOverflow
+		}
+		n = int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) {
+			return reflect.Value{}, fmt.Errorf("%w: %v has fractional part", ErrConversion, f)
+		}
+
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return reflect.Value{}, ErrValueOverflow
+		}
+		n = int64(f)
+	case reflect.String:
+		parsed, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			f, err := parseFloat(v.String())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return toInt(f, t)
+		}
+		n = parsed
+	default:
+		return reflect.Value{}, ErrWrongFieldValueType
+	}
+
+	out := reflect.New(t).Elem()
+	if out.OverflowInt(n) {
+		return reflect.Value{}, ErrValueOverflow
+	}
+	out.SetInt(n)
+
+	return out, nil
+}
+
+// toUint - convert number or string to unsigned int type t with overflow check.
+func toUint(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	var n uint64
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return reflect.Value{}, ErrValueOverflow
+		}
+		n = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) {
+			return reflect.Value{}, fmt.Errorf("%w: %v has fractional part", ErrConversion, f)
+		}
+
+		if f < 0 || f >= math.MaxUint64 {
+			return reflect.Value{}, ErrValueOverflow
+		}
+		n = uint64(f)
+	case reflect.String:
+		parsed, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			f, err := parseFloat(v.String())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return toUint(f, t)
+		}
+		n = parsed
+	default:
+		return reflect.Value{}, ErrWrongFieldValueType
+	}
+
+	out := reflect.New(t).Elem()
+	if out.OverflowUint(n) {
+		return reflect.Value{}, ErrValueOverflow
+	}
+	out.SetUint(n)
+
+	return out, nil
+}
+
+// toFloat - convert number or string to float type t with overflow check.
+func toFloat(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	var f float64
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	case reflect.String:
+		parsed, err := parseFloat(v.String())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		f = parsed.Float()
+	default:
+		return reflect.Value{}, ErrWrongFieldValueType
+	}
+
+	out := reflect.New(t).Elem()
+	if !math.IsInf(f, 0) && out.OverflowFloat(f) {
+		return reflect.Value{}, ErrValueOverflow
+	}
+	out.SetFloat(f)
+
+	return out, nil
+}
+
+// parseFloat - parse string to float64 value.
+func parseFloat(s string) (reflect.Value, error) {
+	f, err := conv.StringToFloat64(s)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %v", ErrConversion, err)
+	}
+
+	return reflect.ValueOf(f), nil
+}
+
+// parseTime - parse string to time.Time by one of timeLayouts.
+func parseTime(s string) (reflect.Value, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return reflect.ValueOf(t), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("%w: cannot parse time from %q", ErrConversion, s)
+}
+
+// textFloatSpec - float formatting by WithFloatPrecision with rmath.RoundPy, the shortest form without it.
+func textFloatSpec(o *options) (roundSpec, error) {
+	spec := roundSpec{precision: -1, round: rmath.RoundPy, mode: RoundPython}
+	if !o.hasFloatPrec {
+		return spec, nil
+	}
+
+	spec.precision = o.floatPrec
+	if !spec.valid() {
+		return spec, precisionError(ErrInvalidOption, spec)
+	}
+
+	return spec, nil
+}
+
+// fieldFloatSpec - float formatting of field and its nested fields by round tag, `round:"-"` for the shortest form.
+func fieldFloatSpec(tag reflect.StructTag, parent roundSpec) (roundSpec, error) {
+	spec, ok, err := parseRoundTag(tag, parent)
+	if !ok && err == nil {
+		spec.precision = -1
+	}
+
+	return spec, err
+}
+
+// formatText - text of value parsed back by parseText: text of encoding.TextMarshaler, number, string, bool,
+// slice or map of them joined by ','.
+func formatText(v reflect.Value, spec roundSpec) (string, error) {
+	// nil check
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", nil
+	}
+
+	switch t := v.Type(); {
+	case t.Implements(textMarshalerType):
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", typeError(fmt.Errorf("%w: %v", ErrConversion, err), nil, t)
+		}
+
+		return string(text), nil
+	case t == durationType:
+		return v.Interface().(time.Duration).String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return formatText(v.Elem(), spec)
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(v.Float(), v.Type().Bits(), spec), nil
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			part, err := formatText(v.Index(i), spec)
+			if err != nil {
+				return "", err
+			}
+
+			parts[i] = part
+		}
+
+		return strings.Join(parts, ","), nil
+	case reflect.Map:
+		keys := v.MapKeys()
+		sortKeys(keys)
+
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			k, err := formatText(key, spec)
+			if err != nil {
+				return "", err
+			}
+
+			elem, err := formatText(v.MapIndex(key), spec)
+			if err != nil {
+				return "", err
+			}
+
+			parts[i] = k + ":" + elem
+		}
+
+		return strings.Join(parts, ","), nil
+	default:
+		return "", typeError(ErrWrongFieldValueType, nil, v.Type())
+	}
+}
+
+// formatFloat - float with fixed precision of spec, the shortest form for negative precision.
+func formatFloat(f float64, bits int, spec roundSpec) string {
+	if spec.precision < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'f', -1, bits)
+	}
+
+	return strconv.FormatFloat(spec.round(f, spec.precision), 'f', spec.precision, bits)
+}
+
+// setText - set field from text like SetDefaults.
+func setText(field reflect.Value, text string) error {
+	value, err := parseText(text, field.Type())
+	if err != nil {
+		return err
+	}
+
+	return assign(field, value, textOptions)
+}
+
+// isFlatStruct - struct or struct ptr type flattened to CSV columns and Flatten keys.
+// time.Time, encoding.TextMarshaler and encoding.TextUnmarshaler structs are single values.
+func isFlatStruct(t reflect.Type) bool {
+	return isEnvStruct(t) && !t.Implements(textMarshalerType)
+}
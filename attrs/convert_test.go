@@ -0,0 +1,159 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type convertLevel int
+
+// UnmarshalText - test encoding.TextUnmarshaler.
+func (l *convertLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+
+	return nil
+}
+
+type convertName string
+
+type convertConfig struct {
+	Int64    int64
+	Int8     int8
+	Uint     uint
+	Float32  float32
+	Float64  float64
+	Bool     bool
+	Time     time.Time
+	Duration time.Duration
+	Name     convertName
+	Level    convertLevel
+	Ptr      *int
+	Any      interface{}
+}
+
+func TestSetAttrConvert(t *testing.T) {
+	one := 1
+
+	testCases := []struct {
+		fieldName   string
+		newValue    interface{}
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{fieldName: "Int64", newValue: 5, expected: int64(5), testName: "OK. int to int64"},
+		{fieldName: "Int8", newValue: int64(-128), expected: int8(-128), testName: "OK. int64 to int8"},
+		{fieldName: "Int8", newValue: 3.0, expected: int8(3), testName: "OK. Integral float64 to int8"},
+		{fieldName: "Int64", newValue: "42", expected: int64(42), testName: "OK. String to int64"},
+		{fieldName: "Int64", newValue: "1e3", expected: int64(1000), testName: "OK. Float string to int64"},
+		{fieldName: "Uint", newValue: int32(7), expected: uint(7), testName: "OK. int32 to uint"},
+		{fieldName: "Float32", newValue: 1.5, expected: float32(1.5), testName: "OK. float64 to float32"},
+		{fieldName: "Float64", newValue: "1.25", expected: 1.25, testName: "OK. String to float64"},
+		{fieldName: "Float64", newValue: uint8(2), expected: 2.0, testName: "OK. uint8 to float64"},
+		{fieldName: "Bool", newValue: "true", expected: true, testName: "OK. String to bool"},
+		{
+			fieldName: "Time",
+			newValue:  "2024-06-01T10:00:00Z",
+			expected:  time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			testName:  "OK. RFC3339 string to time.Time",
+		},
+		{
+			fieldName: "Time",
+			newValue:  "2024-06-01",
+			expected:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			testName:  "OK. Date string to time.Time",
+		},
+		{fieldName: "Duration", newValue: "1m30s", expected: 90 * time.Second, testName: "OK. String to time.Duration"},
+		{fieldName: "Duration", newValue: 1000, expected: time.Microsecond, testName: "OK. int to time.Duration"},
+		{fieldName: "Name", newValue: "name", expected: convertName("name"), testName: "OK. Named type with same kind"},
+		{fieldName: "Level", newValue: "high", expected: convertLevel(2), testName: "OK. TextUnmarshaler"},
+		{fieldName: "Ptr", newValue: int64(3), expected: 3, testName: "OK. Ptr field"},
+		{fieldName: "Int64", newValue: &one, expected: int64(1), testName: "OK. Ptr value"},
+		{fieldName: "Any", newValue: 1.5, expected: 1.5, testName: "OK. Interface field"},
+		{fieldName: "Int8", newValue: 128, expectedErr: ErrValueOverflow, testName: "ERR. int8 overflow"},
+		{fieldName: "Uint", newValue: -1, expectedErr: ErrValueOverflow, testName: "ERR. Negative to uint"},
+		{fieldName: "Float32", newValue: 1e300, expectedErr: ErrValueOverflow, testName: "ERR. float32 overflow"},
+		{fieldName: "Int64", newValue: 1.5, expectedErr: ErrConversion, testName: "ERR. Fractional float to int64"},
+		{fieldName: "Int64", newValue: "abc", expectedErr: ErrConversion, testName: "ERR. Not number string"},
+		{fieldName: "Bool", newValue: "yes", expectedErr: ErrConversion, testName: "ERR. Not bool string"},
+		{fieldName: "Time", newValue: "01.06.2024", expectedErr: ErrConversion, testName: "ERR. Unknown time layout"},
+		{fieldName: "Level", newValue: "medium", expectedErr: ErrConversion, testName: "ERR. TextUnmarshaler error"},
+		{fieldName: "Bool", newValue: 1, expectedErr: ErrWrongFieldValueType, testName: "ERR. int to bool"},
+		{fieldName: "Int64", newValue: nil, expectedErr: ErrWrongFieldValueType, testName: "ERR. Nil value"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetAttr")
+			t.Description("Check func `SetAttr` with `WithConvert` option")
+			t.WithParameters(
+				allure.NewParameter("fieldName", testCase.fieldName),
+				allure.NewParameter("newValue", testCase.newValue),
+			)
+
+			obj := &convertConfig{}
+			if err := SetAttr(obj, testCase.newValue, testCase.fieldName, WithConvert()); err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("SetAttr error: %v", err))
+				t.Assert().Equal(&convertConfig{}, obj, "Check field not changed")
+				return
+			}
+
+			t.Assert().Nil(testCase.expectedErr, "Check SetAttr error")
+
+			actual, err := GetAttr(*obj, testCase.fieldName)
+			t.Assert().NoError(err, "Check GetAttr")
+			t.Assert().Equal(testCase.expected, actual, "Check SetAttr")
+		})
+	}
+}
+
+func TestSetAttrNoConvert(t *testing.T) {
+	runner.Run(t, "ERR. No conversion without option", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetAttr")
+		t.Description("Check func `SetAttr` keeps strict types check without `WithConvert` option")
+
+		err := SetAttr(&convertConfig{}, 5, "Int64")
+		t.Assert().ErrorIs(err, ErrWrongFieldValueType)
+	})
+}
+
+func ExampleWithConvert() {
+	type Config struct {
+		Port    int64
+		Ratio   float64
+		Timeout time.Duration
+		Debug   bool
+	}
+
+	cfg := &Config{}
+	values := map[string]interface{}{"Port": 8080, "Ratio": "0.75", "Timeout": "5s", "Debug": "true"}
+
+	for name, value := range values {
+		if err := SetAttr(cfg, value, name, WithConvert()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Printf("%+v\n", *cfg)
+	// Output: {Port:8080 Ratio:0.75 Timeout:5s Debug:true}
+}
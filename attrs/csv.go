@@ -0,0 +1,428 @@
+package attrs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// csvTagKey - struct tag key of CSV column name like `csv:"price"`.
+const csvTagKey = "csv"
+
+// WithCSVComma - CSV field delimiter instead of ','.
+func WithCSVComma(comma rune) Option {
+	return func(o *options) {
+		o.csvComma = comma
+	}
+}
+
+// CSVWriter - writes structs as CSV rows. Columns are exported fields in struct order named by tag
+// `csv:"name"` or Go name, nested structs and struct ptrs are flattened to dotted columns like `Customer.Name`.
+// Floats are formatted with precision of `round:"2"` tag or WithFloatPrecision by rmath.RoundPy,
+// tag mode like `round:"2,floor"` replaces it. Floats without precision are formatted in the shortest form.
+type CSVWriter struct {
+	w       *csv.Writer
+	o       *options
+	typ     reflect.Type // struct type of rows, set by the header
+	columns []csvColumn
+	record  []string
+	rows    int // rows written
+}
+
+// NewCSVWriter - CSVWriter to w. Rows are buffered, call Flush after the last one.
+// 'w': destination of CSV.
+// 'opts': optional, WithTagKey for other tag of column names, WithFloatPrecision, WithCSVComma.
+func NewCSVWriter(w io.Writer, opts ...Option) *CSVWriter {
+	o := newOptions(opts)
+
+	cw := csv.NewWriter(w)
+	if o.csvComma != 0 {
+		cw.Comma = o.csvComma
+	}
+
+	return &CSVWriter{w: cw, o: o}
+}
+
+// Write - write struct as CSV row, the first call writes the header. All rows are of the same struct type.
+// Nil ptrs are empty cells. Slices and maps of values are joined by ',' like `a,b` and `k:v`.
+// 'obj': struct or ptr struct.
+func (w *CSVWriter) Write(obj interface{}) error {
+	objValue := reflect.ValueOf(obj)
+	v := objValue
+	// ptr struct check
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return &AttrError{Op: opWriteCSV, Path: indexPath("", w.rows), Err: ErrNilPointer}
+		}
+		v = v.Elem()
+	}
+	// is struct check
+	if v.Kind() != reflect.Struct {
+		return objError(opWriteCSV, ErrNotStruct, objValue)
+	}
+
+	if err := w.header(v.Type()); err != nil {
+		return err
+	}
+	// same struct type check
+	if v.Type() != w.typ {
+		return &AttrError{Op: opWriteCSV, Path: indexPath("", w.rows), Err: ErrWrongFieldValueType,
+			Expected: w.typ, Actual: v.Type()}
+	}
+
+	for i := range w.columns {
+		c := &w.columns[i]
+
+		cell, err := c.format(v)
+		if err != nil {
+			return fieldError(opWriteCSV, indexPath("", w.rows)+"."+c.name, err)
+		}
+
+		w.record[i] = cell
+	}
+
+	if err := w.w.Write(w.record); err != nil {
+		return err
+	}
+
+	w.rows++
+
+	return nil
+}
+
+// Flush - write buffered rows to io.Writer. Returns error of any previous Write or Flush.
+func (w *CSVWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// header - set columns of struct type t and write the header, once.
+func (w *CSVWriter) header(t reflect.Type) error {
+	if w.typ != nil {
+		return nil
+	}
+
+	columns, err := csvColumns(t, w.o)
+	if err != nil {
+		return opError(opWriteCSV, err)
+	}
+
+	names := make([]string, len(columns))
+	for i := range columns {
+		names[i] = columns[i].name
+	}
+
+	if err := w.w.Write(names); err != nil {
+		return err
+	}
+
+	w.typ, w.columns, w.record = t, columns, make([]string, len(columns))
+
+	return nil
+}
+
+// CSVReader - reads CSV rows into structs. Header cells are matched to columns like in CSVWriter,
+// numbers are parsed by conv.StringToFloat64, other values like WithConvert.
+type CSVReader struct {
+	r       *csv.Reader
+	o       *options
+	typ     reflect.Type // struct type of rows, set by the header
+	columns []*csvColumn // column of every header cell, nil for skipped
+	rows    int          // rows read
+}
+
+// NewCSVReader - CSVReader from r.
+// 'r': source of CSV with header.
+// 'opts': optional, WithTagKey for other tag of column names, WithIgnoreMissing to skip unknown header cells,
+// WithCSVComma.
+func NewCSVReader(r io.Reader, opts ...Option) *CSVReader {
+	o := newOptions(opts)
+
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	if o.csvComma != 0 {
+		cr.Comma = o.csvComma
+	}
+
+	return &CSVReader{r: cr, o: o}
+}
+
+// Read - set struct fields from the next CSV row, the first call reads the header. Returns io.EOF after the last row.
+// Empty cells leave fields unchanged, nil ptrs are allocated for not empty cells only.
+// 'obj': ptr struct, of the same type for all rows.
+func (r *CSVReader) Read(obj interface{}) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opReadCSV, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opReadCSV, ErrNotStruct, objValue)
+	}
+
+	if err := r.bind(objValue.Elem().Type()); err != nil {
+		return err
+	}
+
+	record, err := r.r.Read()
+	if err != nil {
+		return csvError(opReadCSV, err)
+	}
+
+	row := r.rows
+	r.rows++
+
+	for i, cell := range record {
+		c := r.columns[i]
+		if c == nil || cell == "" {
+			continue
+		}
+
+		field, _, err := c.field(objValue.Elem(), true)
+		if err == nil {
+			err = setText(field, cell)
+		}
+
+		if err != nil {
+			return fieldError(opReadCSV, indexPath("", row)+"."+c.name, err)
+		}
+	}
+
+	return nil
+}
+
+// bind - read the header and match its cells to columns of struct type t, once.
+func (r *CSVReader) bind(t reflect.Type) error {
+	if r.typ != nil {
+		// same struct type check
+		if t != r.typ {
+			return &AttrError{Op: opReadCSV, Err: ErrWrongFieldValueType, Expected: r.typ, Actual: t}
+		}
+
+		return nil
+	}
+
+	header, err := r.r.Read()
+	if err != nil {
+		return csvError(opReadCSV, err)
+	}
+
+	columns, err := csvColumns(t, r.o)
+	if err != nil {
+		return opError(opReadCSV, err)
+	}
+
+	byName := make(map[string]*csvColumn, len(columns))
+	for i := range columns {
+		byName[columns[i].name] = &columns[i]
+	}
+
+	r.columns = make([]*csvColumn, len(header))
+
+	for i, name := range header {
+		// byte order mark of excel files
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+
+		c, ok := byName[strings.TrimSpace(name)]
+		if !ok && !r.o.merge.ignoreMissing {
+			return &AttrError{Op: opReadCSV, Path: name, Err: ErrFieldNotInStruct}
+		}
+
+		r.columns[i] = c
+	}
+
+	r.typ = t
+
+	return nil
+}
+
+// WriteCSV - write slice of structs as CSV with header, empty slice is written as header only.
+// 'w': destination of CSV.
+// 'items': slice of structs or struct ptrs.
+// 'opts': optional, same as for NewCSVWriter.
+func WriteCSV[S ~[]E, E any](w io.Writer, items S, opts ...Option) error {
+	if err := checkItems[E](opWriteCSV); err != nil {
+		return err
+	}
+
+	t := reflect.TypeFor[E]()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	cw := NewCSVWriter(w, opts...)
+	if err := cw.header(t); err != nil {
+		return err
+	}
+
+	for i := range items {
+		if err := cw.Write(items[i]); err != nil {
+			return err
+		}
+	}
+
+	return cw.Flush()
+}
+
+// ReadCSV - read all CSV rows into slice of structs.
+// 'r': source of CSV with header.
+// 'opts': optional, same as for NewCSVReader.
+func ReadCSV[T any](r io.Reader, opts ...Option) ([]T, error) {
+	// is struct check
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Struct {
+		return nil, &AttrError{Op: opReadCSV, Err: ErrNotStruct, Actual: t}
+	}
+
+	var (
+		cr  = NewCSVReader(r, opts...)
+		out []T
+	)
+
+	for {
+		var item T
+
+		err := cr.Read(&item)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, item)
+	}
+}
+
+// csvColumn - CSV column of struct field.
+type csvColumn struct {
+	name   string       // header cell, dotted for nested struct fields
+	fields []*fieldInfo // fields from root struct to column field
+	spec   roundSpec    // float formatting, negative precision for the shortest form
+}
+
+// csvColumns - columns of struct type t in fields order.
+func csvColumns(t reflect.Type, o *options) ([]csvColumn, error) {
+	b := csvBuilder{o: &options{tagKey: o.tagKey}, visited: make(map[reflect.Type]bool)}
+	if b.o.tagKey == "" {
+		b.o.tagKey = csvTagKey
+	}
+
+	spec, err := textFloatSpec(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.build(t, nil, "", spec); err != nil {
+		return nil, err
+	}
+
+	return b.columns, nil
+}
+
+// csvBuilder - collects columns of nested structs.
+type csvBuilder struct {
+	o       *options
+	columns []csvColumn
+	visited map[reflect.Type]bool // structs on the current path, recursive fields are skipped
+}
+
+// build - add columns of struct type t fields. Field tag sets float formatting of the field and its nested fields.
+func (b *csvBuilder) build(t reflect.Type, path []*fieldInfo, prefix string, spec roundSpec) error {
+	b.visited[t] = true
+	defer delete(b.visited, t)
+
+	fields := structFields(t, b.o)
+	for i := range fields {
+		f := &fields[i]
+		if !f.exported {
+			continue
+		}
+
+		name := joinName(prefix, f.name)
+
+		fieldSpec, err := fieldFloatSpec(f.tag, spec)
+		if err != nil {
+			return &AttrError{Path: name, Err: err}
+		}
+
+		fieldPath := append(path[:len(path):len(path)], f)
+
+		if !isFlatStruct(f.typ) {
+			b.columns = append(b.columns, csvColumn{name: name, fields: fieldPath, spec: fieldSpec})
+			continue
+		}
+
+		nested := f.typ
+		if nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+
+		if b.visited[nested] {
+			continue
+		}
+
+		if err := b.build(nested, fieldPath, name, fieldSpec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// field - column field of struct value. Nil ptrs on the path are allocated if 'alloc', else reports false.
+func (c *csvColumn) field(v reflect.Value, alloc bool) (reflect.Value, bool, error) {
+	for _, f := range c.fields {
+		// nested struct ptr
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false, nil
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		field, err := fieldByInfo(v, f, alloc)
+		// embedded nil ptr check
+		if errors.Is(err, ErrNilPointer) {
+			return reflect.Value{}, false, nil
+		}
+
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+
+		v = field
+	}
+
+	return v, true, nil
+}
+
+// format - CSV cell of column field of struct value, empty for nil ptr on the path.
+func (c *csvColumn) format(v reflect.Value) (string, error) {
+	field, ok, err := c.field(v, false)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	return formatText(field, c.spec)
+}
+
+// csvError - CSV format error wrapped in ErrInvalidCSV. Other errors like io.EOF are returned as is.
+func csvError(op string, err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &AttrError{Op: op, Err: fmt.Errorf("%w: %v", ErrInvalidCSV, parseErr)}
+	}
+
+	return err
+}
@@ -0,0 +1,430 @@
+package attrs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type csvAddress struct {
+	City string `csv:"city"`
+	Zip  *int   `csv:"zip"`
+}
+
+type csvBase struct {
+	ID int `csv:"id"`
+}
+
+type csvNode struct {
+	Value float64
+	Next  *csvNode
+}
+
+type csvReport struct {
+	csvBase
+	Name      string         `csv:"name"`
+	Price     float64        `csv:"price" round:"2"`
+	Rate      float32        `csv:"rate" round:"1,floor"`
+	Ratio     float64        `csv:"ratio" round:"-"`
+	Amount    *float64       `csv:"amount"`
+	Paid      bool           `csv:"paid"`
+	CreatedAt time.Time      `csv:"created_at"`
+	Timeout   time.Duration  `csv:"timeout"`
+	Tags      []string       `csv:"tags"`
+	Stock     map[string]int `csv:"stock"`
+	Address   csvAddress     `csv:"address"`
+	Billing   *csvAddress    `csv:"billing"`
+	Node      csvNode        `csv:"node"`
+	Skip      string         `csv:"-"`
+	note      string
+}
+
+const csvReportHeader = "id,name,price,rate,ratio,amount,paid,created_at,timeout,tags,stock," +
+	"address.city,address.zip,billing.city,billing.zip,node.Value\n"
+
+func TestWriteCSV(t *testing.T) {
+	var (
+		amount = 10.005
+		zip    = 101000
+	)
+
+	testCases := []struct {
+		items       []*csvReport
+		opts        []Option
+		expected    string
+		expectedErr string
+		testName    string
+	}{
+		{
+			items: []*csvReport{
+				{
+					csvBase:   csvBase{ID: 1},
+					Name:      "john, jr",
+					Price:     2.675,
+					Rate:      1.99,
+					Ratio:     0.125,
+					Amount:    &amount,
+					Paid:      true,
+					CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Timeout:   time.Minute,
+					Tags:      []string{"a", "b"},
+					Stock:     map[string]int{"b": 2, "a": 1},
+					Address:   csvAddress{City: "moscow", Zip: &zip},
+					Node:      csvNode{Value: 1.5},
+					Skip:      "skip",
+					note:      "note",
+				},
+				{},
+			},
+			expected: csvReportHeader +
+				`1,"john, jr",2.67,1.9,0.125,10.005,true,2024-01-02T03:04:05Z,1m0s,"a,b","a:1,b:2",` +
+				"moscow,101000,,,1.5\n" +
+				"0,,0.00,0.0,0,,false,0001-01-01T00:00:00Z,0s,,,,,,,0\n",
+			testName: "OK. Tags, nested structs, rounding",
+		},
+		{
+			items: []*csvReport{{Amount: func() *float64 { f := 1.0 / 3; return &f }()}},
+			opts:  []Option{WithFloatPrecision(3), WithCSVComma(';')},
+			expected: strings.ReplaceAll(csvReportHeader, ",", ";") +
+				"0;;0.00;0.0;0;0.333;false;0001-01-01T00:00:00Z;0s;;;;;;;0.000\n",
+			testName: "OK. Float precision and comma",
+		},
+		{
+			items:    nil,
+			expected: csvReportHeader,
+			testName: "OK. Header only",
+		},
+		{
+			items:       []*csvReport{{}, nil},
+			expectedErr: "WriteCSV [1]: nil pointer in field path",
+			testName:    "ERR. Nil item",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("WriteCSV")
+			t.Description("Check func `WriteCSV`")
+			t.WithParameters(allure.NewParameter("items", len(testCase.items)))
+
+			var buf bytes.Buffer
+
+			err := WriteCSV(&buf, testCase.items, testCase.opts...)
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, buf.String(), "Check WriteCSV")
+		})
+	}
+
+	runner.Run(t, "ERR. Not a struct", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("WriteCSV")
+		t.Description("Check func `WriteCSV` with not struct items")
+
+		t.Assert().EqualError(WriteCSV(io.Discard, []int{1}), "WriteCSV: not a struct (got int)")
+	})
+
+	runner.Run(t, "ERR. Not formatted field", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("WriteCSV")
+		t.Description("Check func `WriteCSV` with field of not supported type")
+
+		type item struct {
+			Items []csvAddress
+		}
+
+		err := WriteCSV(io.Discard, []item{{Items: []csvAddress{{}}}})
+		t.Assert().EqualError(err, "WriteCSV [0].Items: wrong field value type (got attrs.csvAddress)")
+	})
+
+	runner.Run(t, "ERR. Invalid round tag", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("WriteCSV")
+		t.Description("Check func `WriteCSV` with invalid round tag")
+
+		type item struct {
+			Price float64 `round:"two"`
+		}
+
+		err := WriteCSV(io.Discard, []item{{}})
+		t.Assert().ErrorIs(err, ErrInvalidTag)
+		t.Assert().EqualError(err, `WriteCSV Price: invalid struct tag: round precision "two"`)
+	})
+}
+
+func TestCSVWriter(t *testing.T) {
+	runner.Run(t, "ERR. Rows of different types", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("CSVWriter")
+		t.Description("Check method `Write` with rows of different types")
+
+		w := NewCSVWriter(io.Discard)
+		t.Require().NoError(w.Write(csvAddress{City: "moscow"}))
+		t.Require().NoError(w.Write(&csvAddress{City: "kazan"}))
+		t.Assert().EqualError(w.Write(csvBase{}),
+			"WriteCSV [2]: wrong field value type (expected attrs.csvAddress, got attrs.csvBase)")
+		t.Assert().EqualError(w.Write(1), "WriteCSV: not a struct (got int)")
+		t.Assert().NoError(w.Flush())
+	})
+
+	runner.Run(t, "ERR. Write error", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("CSVWriter")
+		t.Description("Check method `Flush` returns error of io.Writer")
+
+		w := NewCSVWriter(errWriter{})
+		t.Require().NoError(w.Write(csvAddress{City: "moscow"}))
+		t.Assert().ErrorIs(w.Flush(), os.ErrClosed)
+	})
+}
+
+func TestReadCSV(t *testing.T) {
+	var (
+		zip    = 101000
+		amount = 10.005
+	)
+
+	testCases := []struct {
+		input       string
+		opts        []Option
+		expected    []csvReport
+		expectedErr error
+		errPath     string
+		testName    string
+	}{
+		{
+			input: csvReportHeader +
+				`1,"john, jr",2.67,1.9,0.3,10.005,true,2024-01-02T03:04:05Z,1m0s,"a,b","a:1,b:2",moscow,101000,,,1.5` + "\n" +
+				",,,,,,,,,,,,,kazan,,\n",
+			expected: []csvReport{
+				{
+					csvBase:   csvBase{ID: 1},
+					Name:      "john, jr",
+					Price:     2.67,
+					Rate:      1.9,
+					Ratio:     0.3,
+					Amount:    &amount,
+					Paid:      true,
+					CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Timeout:   time.Minute,
+					Tags:      []string{"a", "b"},
+					Stock:     map[string]int{"a": 1, "b": 2},
+					Address:   csvAddress{City: "moscow", Zip: &zip},
+					Node:      csvNode{Value: 1.5},
+				},
+				{Billing: &csvAddress{City: "kazan"}},
+			},
+			testName: "OK. All columns",
+		},
+		{
+			input:    "\ufeff name ;unknown;address.zip\njohn;x;101000\n",
+			opts:     []Option{WithIgnoreMissing(), WithCSVComma(';')},
+			expected: []csvReport{{Name: "john", Address: csvAddress{Zip: &zip}}},
+			testName: "OK. Some columns, unknown skipped",
+		},
+		{
+			input:    "",
+			expected: nil,
+			testName: "OK. Empty input",
+		},
+		{
+			input:       "name,unknown\njohn,x\n",
+			expectedErr: ErrFieldNotInStruct,
+			errPath:     "unknown",
+			testName:    "ERR. Unknown column",
+		},
+		{
+			input:       "name,price\njohn,1.5\njane,1,5\n",
+			expectedErr: ErrInvalidCSV,
+			testName:    "ERR. Wrong number of cells",
+		},
+		{
+			input:       "name,price\njohn,1.5\njane,1.5.1\n",
+			expectedErr: ErrConversion,
+			errPath:     "[1].price",
+			testName:    "ERR. Invalid float",
+		},
+		{
+			input:       "address.zip\n1e20\n",
+			expectedErr: ErrValueOverflow,
+			errPath:     "[0].address.zip",
+			testName:    "ERR. Overflow",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("ReadCSV")
+			t.Description("Check func `ReadCSV`")
+			t.WithParameters(allure.NewParameter("input", testCase.input))
+
+			actual, err := ReadCSV[csvReport](strings.NewReader(testCase.input), testCase.opts...)
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("ReadCSV error: %v", err))
+
+				var attrErr *AttrError
+				t.Require().True(errors.As(err, &attrErr))
+				t.Assert().Equal(opReadCSV, attrErr.Op)
+				t.Assert().Equal(testCase.errPath, attrErr.Path)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, actual, "Check ReadCSV")
+		})
+	}
+
+	runner.Run(t, "ERR. Not a struct", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ReadCSV")
+		t.Description("Check func `ReadCSV` with not struct type")
+
+		_, err := ReadCSV[*csvReport](strings.NewReader("name\n"))
+		t.Assert().EqualError(err, "ReadCSV: not a struct (got *attrs.csvReport)")
+	})
+}
+
+func TestCSVReader(t *testing.T) {
+	runner.Run(t, "OK. Round trip by rows", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("CSVReader")
+		t.Description("Check CSVWriter output is read by CSVReader")
+
+		var (
+			buf     bytes.Buffer
+			amount  = 10.005
+			zip     = 101000
+			reports = []csvReport{
+				{
+					csvBase:   csvBase{ID: 1},
+					Name:      "john, jr",
+					Price:     2.675,
+					Rate:      1.99,
+					Ratio:     0.125,
+					Amount:    &amount,
+					Paid:      true,
+					CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Timeout:   time.Minute,
+					Tags:      []string{"a", "b"},
+					Stock:     map[string]int{"b": 2, "a": 1},
+					Address:   csvAddress{City: "moscow", Zip: &zip},
+					Node:      csvNode{Value: 1.5},
+					Skip:      "skip",
+					note:      "note",
+				},
+				{Name: "jane", Billing: &csvAddress{City: "kazan"}},
+			}
+			// prices rounded by tags, skipped and unexported fields are not read
+			expected = []csvReport{
+				{
+					csvBase:   csvBase{ID: 1},
+					Name:      "john, jr",
+					Price:     2.67,
+					Rate:      1.9,
+					Ratio:     0.125,
+					Amount:    &amount,
+					Paid:      true,
+					CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Timeout:   time.Minute,
+					Tags:      []string{"a", "b"},
+					Stock:     map[string]int{"b": 2, "a": 1},
+					Address:   csvAddress{City: "moscow", Zip: &zip},
+					Node:      csvNode{Value: 1.5},
+				},
+				{Name: "jane", Billing: &csvAddress{City: "kazan"}},
+			}
+		)
+
+		w := NewCSVWriter(&buf)
+		for _, report := range reports {
+			t.Require().NoError(w.Write(report))
+		}
+		t.Require().NoError(w.Flush())
+
+		r := NewCSVReader(&buf)
+		for _, report := range expected {
+			var actual csvReport
+			t.Require().NoError(r.Read(&actual))
+			t.Assert().Equal(report, actual)
+		}
+
+		t.Assert().ErrorIs(r.Read(&csvReport{}), io.EOF)
+	})
+
+	runner.Run(t, "ERR. Wrong obj", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("CSVReader")
+		t.Description("Check method `Read` with wrong obj")
+
+		r := NewCSVReader(strings.NewReader("city\nmoscow\nkazan\n"))
+		t.Assert().ErrorIs(r.Read(csvAddress{}), ErrNotPointerStruct)
+		t.Assert().ErrorIs(r.Read(new(int)), ErrNotStruct)
+		t.Require().NoError(r.Read(&csvAddress{}))
+		t.Assert().EqualError(r.Read(&csvBase{}),
+			"ReadCSV: wrong field value type (expected attrs.csvAddress, got attrs.csvBase)")
+	})
+}
+
+// errWriter - io.Writer which always fails.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
+
+func ExampleWriteCSV() {
+	type Customer struct {
+		Name string `csv:"name"`
+	}
+
+	type Order struct {
+		ID       int       `csv:"id"`
+		Customer *Customer `csv:"customer"`
+		Price    float64   `csv:"price" round:"2"`
+	}
+
+	orders := []Order{{ID: 1, Customer: &Customer{Name: "john"}, Price: 2.675}, {ID: 2, Price: 10}}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, orders); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(buf.String())
+
+	read, err := ReadCSV[Order](&buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(read[0].ID, read[0].Customer.Name, read[0].Price, read[1].Customer)
+	// Output:
+	// id,customer.name,price
+	// 1,john,2.67
+	// 2,,10.00
+	// 1 john 2.67 <nil>
+}
@@ -0,0 +1,199 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// defaultTagKey - struct tag key of field default value like `default:"8080"`.
+const defaultTagKey = "default"
+
+// SetDefaults - set zero struct fields to values from tag `default:"..."`. Values are parsed like SetAttr
+// with WithConvert: numbers by conv.StringToFloat64, bool, time.Duration, time.Time, encoding.TextUnmarshaler,
+// comma-separated slices and `key:value` maps. Walks into nested structs and struct ptrs,
+// nil ptrs are allocated if they have fields with defaults, recursive ones are kept nil. Non-zero fields are kept.
+// 'obj': ptr struct.
+// 'opts': optional, WithTagKey for tag names in error paths.
+func SetDefaults(obj interface{}, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opSetDefaults, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opSetDefaults, ErrNotStruct, objValue)
+	}
+
+	o := newOptions(opts)
+	// default values are always converted
+	convert := &options{convert: true, tagKey: o.tagKey}
+
+	// root ptr is on the path too, protects from cycles back to it
+	d := defaulter{
+		o:       convert,
+		visited: make(map[reflect.Type]bool),
+		ptrs:    map[ptrKey]bool{{addr: objValue.Pointer(), typ: objValue.Type()}: true},
+	}
+
+	if err := d.setDefaults(objValue.Elem(), ""); err != nil {
+		return opError(opSetDefaults, err)
+	}
+
+	return nil
+}
+
+// defaulter - sets defaults of struct fields.
+type defaulter struct {
+	o       *options
+	visited map[reflect.Type]bool // structs on the current path, recursive nil ptrs are not allocated
+	ptrs    map[ptrKey]bool       // ptrs on the current path, protects from cycles
+}
+
+// setDefaults - set defaults of struct value fields recursively.
+func (d *defaulter) setDefaults(v reflect.Value, prefix string) error {
+	d.visited[v.Type()] = true
+	defer delete(d.visited, v.Type())
+
+	fields := structFields(v.Type(), d.o)
+	for i := range fields {
+		f := &fields[i]
+		path := joinName(prefix, f.name)
+
+		value, tagged := f.tag.Lookup(defaultTagKey)
+		if !tagged && (!f.exported || !hasDefaults(f.typ, nil)) {
+			continue
+		}
+		// is tagged field exported
+		if !f.exported {
+			return &AttrError{Path: path, Err: ErrUnexportedField}
+		}
+		// embedded nil ptrs are allocated
+		field, err := fieldByInfo(v, f, true)
+		if err != nil {
+			return &AttrError{Path: path, Err: err}
+		}
+		// nested struct or struct ptr
+		if !tagged {
+			if err := d.setNested(field, path); err != nil {
+				return err
+			}
+
+			continue
+		}
+		// non-zero field is kept
+		if !field.IsZero() || value == "" {
+			continue
+		}
+
+		newValue, err := parseText(value, field.Type())
+		if err != nil {
+			return fieldError("", path, err)
+		}
+
+		if err := assign(field, newValue, d.o); err != nil {
+			return fieldError("", path, err)
+		}
+	}
+
+	return nil
+}
+
+// setNested - set defaults of nested struct or struct ptr field. Nil ptr to struct on the current path
+// is not allocated, ptr on the current path is not entered.
+func (d *defaulter) setNested(field reflect.Value, path string) error {
+	if field.Kind() != reflect.Ptr {
+		return d.setDefaults(field, path)
+	}
+	// recursive nil ptr and cycle check
+	key := ptrKey{addr: field.Pointer(), typ: field.Type()}
+	if field.IsNil() && d.visited[field.Type().Elem()] || !field.IsNil() && d.ptrs[key] {
+		return nil
+	}
+
+	nested, _ := derefAlloc(field, reflect.Struct)
+	key.addr = field.Pointer()
+
+	d.ptrs[key] = true
+	defer delete(d.ptrs, key)
+
+	return d.setDefaults(nested, path)
+}
+
+// parseText - value of type t parsed from text like SetAttr with WithConvert.
+// Slices are comma-separated, maps are comma-separated `key:value` pairs.
+func parseText(value string, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		parts := strings.Split(value, ",")
+		out := reflect.MakeSlice(t, 0, len(parts))
+
+		for _, part := range parts {
+			elem, err := convertValue(reflect.ValueOf(strings.TrimSpace(part)), t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out = reflect.Append(out, elem)
+		}
+
+		return out, nil
+	case reflect.Map:
+		parts := strings.Split(value, ",")
+		out := reflect.MakeMapWithSize(t, len(parts))
+
+		for _, part := range parts {
+			k, v, ok := strings.Cut(part, ":")
+			if !ok {
+				err := fmt.Errorf("%w: map entry %q, expected key:value", ErrConversion, part)
+				return reflect.Value{}, typeError(err, t, reflect.TypeOf(value))
+			}
+
+			key, err := convertValue(reflect.ValueOf(strings.TrimSpace(k)), t.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			elem, err := convertValue(reflect.ValueOf(strings.TrimSpace(v)), t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out.SetMapIndex(key, elem)
+		}
+
+		return out, nil
+	default:
+		return convertValue(reflect.ValueOf(value), t)
+	}
+}
+
+// hasDefaults - struct or struct ptr type has fields with default tag, also nested.
+func hasDefaults(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+
+	if visited == nil {
+		visited = make(map[reflect.Type]bool)
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup(defaultTagKey); ok || hasDefaults(sf.Type, visited) {
+			return true
+		}
+	}
+
+	return false
+}
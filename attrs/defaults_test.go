@@ -0,0 +1,223 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type defaultsDB struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"5432"`
+	Timeout time.Duration `default:"5s"`
+}
+
+type defaultsConfig struct {
+	Name    string             `json:"name" default:"app"`
+	Debug   bool               `default:"true"`
+	Rate    float64            `default:"0.5"`
+	Limit   *int               `default:"1e3"`
+	Tags    []string           `default:"a, b"`
+	Ports   []int              `default:"80,443"`
+	Weights map[string]float64 `default:"a:1.5,b:2"`
+	Start   time.Time          `default:"2024-01-02"`
+	DB      defaultsDB
+	Replica *defaultsDB
+	Backup  *defaultsDB `json:"backup"`
+	Other   *fieldAddress
+	secret  string
+}
+
+// defaultsNode - struct with recursive ptr field.
+type defaultsNode struct {
+	Val  int `default:"1"`
+	Next *defaultsNode
+}
+
+func TestSetDefaults(t *testing.T) {
+	limit := 10
+
+	testCases := []struct {
+		obj         interface{}
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: &defaultsConfig{},
+			expected: &defaultsConfig{
+				Name:    "app",
+				Debug:   true,
+				Rate:    0.5,
+				Limit:   func() *int { l := 1000; return &l }(),
+				Tags:    []string{"a", "b"},
+				Ports:   []int{80, 443},
+				Weights: map[string]float64{"a": 1.5, "b": 2},
+				Start:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				DB:      defaultsDB{Host: "localhost", Port: 5432, Timeout: 5 * time.Second},
+				Replica: &defaultsDB{Host: "localhost", Port: 5432, Timeout: 5 * time.Second},
+				Backup:  &defaultsDB{Host: "localhost", Port: 5432, Timeout: 5 * time.Second},
+			},
+			testName: "OK. Zero struct",
+		},
+		{
+			obj: &defaultsConfig{
+				Name:    "name",
+				Limit:   &limit,
+				Ports:   []int{},
+				DB:      defaultsDB{Port: 1},
+				Replica: &defaultsDB{Host: "replica"},
+				secret:  "secret",
+			},
+			expected: &defaultsConfig{
+				Name:    "name",
+				Debug:   true,
+				Rate:    0.5,
+				Limit:   &limit,
+				Tags:    []string{"a", "b"},
+				Ports:   []int{},
+				Weights: map[string]float64{"a": 1.5, "b": 2},
+				Start:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				DB:      defaultsDB{Host: "localhost", Port: 1, Timeout: 5 * time.Second},
+				Replica: &defaultsDB{Host: "replica", Port: 5432, Timeout: 5 * time.Second},
+				Backup:  &defaultsDB{Host: "localhost", Port: 5432, Timeout: 5 * time.Second},
+				secret:  "secret",
+			},
+			testName: "OK. Non-zero fields kept",
+		},
+		{
+			obj:      &defaultsNode{},
+			expected: &defaultsNode{Val: 1},
+			testName: "OK. Recursive nil ptr not allocated",
+		},
+		{
+			obj:      &defaultsNode{Val: 2, Next: &defaultsNode{Next: &defaultsNode{}}},
+			expected: &defaultsNode{Val: 2, Next: &defaultsNode{Val: 1, Next: &defaultsNode{Val: 1}}},
+			testName: "OK. Recursive ptrs set",
+		},
+		{
+			obj: &struct {
+				Port int `default:"port"`
+			}{},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Unparsable default",
+		},
+		{
+			obj: &struct {
+				Port uint8 `default:"256"`
+			}{},
+			expectedErr: ErrValueOverflow,
+			testName:    "ERR. Default overflow",
+		},
+		{
+			obj: &struct {
+				Weights map[string]int `default:"a"`
+			}{},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Invalid map default",
+		},
+		{
+			obj: &struct {
+				port int `default:"80"`
+			}{},
+			expectedErr: ErrUnexportedField,
+			testName:    "ERR. Unexported field",
+		},
+		{
+			obj:         defaultsConfig{},
+			expectedErr: ErrNotPointerStruct,
+			testName:    "ERR. Not a ptr",
+		},
+		{
+			obj:         &limit,
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetDefaults")
+			t.Description("Check func `SetDefaults`")
+
+			err := SetDefaults(testCase.obj)
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("SetDefaults error: %v", err))
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check SetDefaults")
+		})
+	}
+}
+
+func TestSetDefaultsCycle(t *testing.T) {
+	runner.Run(t, "OK. Ptr cycle", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetDefaults")
+		t.Description("Check func `SetDefaults` with ptr cycle")
+
+		node := &defaultsNode{}
+		node.Next = &defaultsNode{Next: node}
+
+		t.Require().NoError(SetDefaults(node))
+		t.Assert().Equal(1, node.Val)
+		t.Assert().Equal(1, node.Next.Val)
+		t.Assert().Same(node, node.Next.Next)
+	})
+}
+
+func TestSetDefaultsPath(t *testing.T) {
+	runner.Run(t, "ERR. Path in error", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetDefaults")
+		t.Description("Check func `SetDefaults` error path")
+		t.WithParameters(allure.NewParameter("tagKey", "json"))
+
+		type DB struct {
+			Port int `json:"port" default:"port"`
+		}
+
+		type Config struct {
+			DB *DB `json:"db"`
+		}
+
+		err := SetDefaults(&Config{}, WithTagKey("json"))
+		t.Require().ErrorIs(err, ErrConversion)
+		t.Assert().Equal("SetDefaults db.port: value conversion failed: unparsed tail left after parsing "+
+			"float64 from \"port\": \"port\" (expected int, got string)", err.Error())
+	})
+}
+
+func ExampleSetDefaults() {
+	type DB struct {
+		Host    string        `default:"localhost"`
+		Port    int           `default:"5432"`
+		Timeout time.Duration `default:"5s"`
+	}
+
+	type Config struct {
+		Name  string   `default:"app"`
+		Hosts []string `default:"a,b"`
+		DB    *DB
+	}
+
+	cfg := Config{Name: "service"}
+	if err := SetDefaults(&cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(cfg.Name, cfg.Hosts, *cfg.DB)
+	// Output: service [a b] {localhost 5432 5s}
+}
@@ -0,0 +1,430 @@
+package attrs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/ruauka/tools-go/rmath"
+)
+
+// ChangeKind - kind of field change.
+type ChangeKind int
+
+// field change kinds.
+const (
+	ChangeModified ChangeKind = iota + 1 // value changed
+	ChangeAdded                          // ptr became not nil, slice element or map key added
+	ChangeRemoved                        // ptr became nil, slice element or map key removed
+)
+
+// String - change kind name.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeModified:
+		return "modified"
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return "ChangeKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Change - field change found by Diff. Path has the same syntax as in GetAttr and SetAttr.
+type Change struct {
+	Path string      // field path like `Items[2].Price`
+	Old  interface{} // old value, nil if added
+	New  interface{} // new value, nil if removed
+	Kind ChangeKind  // change kind
+}
+
+// String - change like `modified Items[2].Price: 1.5 -> 2.5`.
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %v -> %v", c.Kind, c.Path, c.Old, c.New)
+}
+
+// WithFloatPrecision - compare floats after rmath.Round to precision, so rounding noise is not reported.
+// CSVWriter and Flatten format floats without precision in round tag to it, precision must be in 0..13.
+func WithFloatPrecision(precision int) Option {
+	return func(o *options) {
+		o.floatPrec = precision
+		o.hasFloatPrec = true
+	}
+}
+
+// Diff - field-level changes between two structs of the same type.
+// Recurses into nested structs, pointers, slices, arrays and maps. Unexported fields are skipped.
+// Pointer pairs already compared on the current path are equal, so cycles are safe.
+// 'a', 'b': struct or ptr struct of the same type.
+// 'opts': optional, WithFloatPrecision to compare rounded floats, WithTagKey for tag names in paths.
+func Diff(a, b interface{}, opts ...Option) ([]Change, error) {
+	var (
+		aValue = reflect.ValueOf(a)
+		bValue = reflect.ValueOf(b)
+	)
+	// ptr struct check
+	if aValue.Kind() == reflect.Ptr && bValue.Kind() == reflect.Ptr {
+		aValue, bValue = aValue.Elem(), bValue.Elem()
+	}
+	// is struct check
+	if aValue.Kind() != reflect.Struct {
+		return nil, objError(opDiff, ErrNotStruct, aValue)
+	}
+	// types check
+	if aValue.Type() != valueType(bValue) {
+		return nil, &AttrError{Op: opDiff, Err: ErrWrongFieldValueType, Expected: aValue.Type(), Actual: valueType(bValue)}
+	}
+
+	d := &differ{o: newOptions(opts)}
+	d.diffStruct("", aValue, bValue)
+
+	return d.changes, nil
+}
+
+// differ - collects changes while walking two values.
+type differ struct {
+	o       *options
+	changes []Change
+	visited map[[2]ptrKey]bool // pointer pairs on the current path, protects from cycles
+}
+
+// add - add change.
+func (d *differ) add(path string, kind ChangeKind, a, b reflect.Value) {
+	change := Change{Path: path, Kind: kind}
+	if a.IsValid() {
+		change.Old = a.Interface()
+	}
+
+	if b.IsValid() {
+		change.New = b.Interface()
+	}
+
+	d.changes = append(d.changes, change)
+}
+
+// diff - compare two values of the same type.
+func (d *differ) diff(path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil():
+			d.add(path, ChangeAdded, reflect.Value{}, b.Elem())
+		case b.IsNil():
+			d.add(path, ChangeRemoved, a.Elem(), reflect.Value{})
+		default:
+			d.diffPtr(path, a, b)
+		}
+	case reflect.Interface:
+		if !a.IsNil() && !b.IsNil() && a.Elem().Type() == b.Elem().Type() {
+			d.diff(path, a.Elem(), b.Elem())
+			return
+		}
+
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(path, ChangeModified, a, b)
+		}
+	case reflect.Struct:
+		if eq, ok := equalMethod(a, b); ok {
+			if !eq {
+				d.add(path, ChangeModified, a, b)
+			}
+			return
+		}
+
+		d.diffStruct(path, a, b)
+	case reflect.Slice, reflect.Array:
+		d.diffSlice(path, a, b)
+	case reflect.Map:
+		d.diffMap(path, a, b)
+	case reflect.Float32, reflect.Float64:
+		if !floatEqual(a.Float(), b.Float(), a.Type().Bits(), d.o) {
+			d.add(path, ChangeModified, a, b)
+		}
+	default:
+		if !valueEqual(a, b) {
+			d.add(path, ChangeModified, a, b)
+		}
+	}
+}
+
+// diffPtr - compare not nil pointers by pointed values. The same ptr and pair on the current path are equal.
+func (d *differ) diffPtr(path string, a, b reflect.Value) {
+	// the same ptr and cycle check
+	key := [2]ptrKey{{addr: a.Pointer(), typ: a.Type()}, {addr: b.Pointer(), typ: b.Type()}}
+	if a.Pointer() == b.Pointer() || d.visited[key] {
+		return
+	}
+
+	if d.visited == nil {
+		d.visited = make(map[[2]ptrKey]bool)
+	}
+
+	d.visited[key] = true
+	defer delete(d.visited, key)
+
+	d.diff(path, a.Elem(), b.Elem())
+}
+
+// diffStruct - compare exported fields of two structs.
+func (d *differ) diffStruct(path string, a, b reflect.Value) {
+	fields := structFields(a.Type(), d.o)
+	// struct without exported fields (time.Time, ...) is compared as a whole
+	if !hasExported(fields) {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(path, ChangeModified, a, b)
+		}
+		return
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		if !f.exported {
+			continue
+		}
+
+		aField, aErr := fieldByInfo(a, f, false)
+		bField, bErr := fieldByInfo(b, f, false)
+		// nil embedded ptr
+		switch {
+		case aErr != nil && bErr != nil:
+		case aErr != nil:
+			d.add(joinName(path, f.name), ChangeAdded, reflect.Value{}, bField)
+		case bErr != nil:
+			d.add(joinName(path, f.name), ChangeRemoved, aField, reflect.Value{})
+		default:
+			d.diff(joinName(path, f.name), aField, bField)
+		}
+	}
+}
+
+// diffSlice - compare slices or arrays element by element.
+func (d *differ) diffSlice(path string, a, b reflect.Value) {
+	common := min(a.Len(), b.Len())
+
+	for i := 0; i < common; i++ {
+		d.diff(indexPath(path, i), a.Index(i), b.Index(i))
+	}
+
+	for i := common; i < a.Len(); i++ {
+		d.add(indexPath(path, i), ChangeRemoved, a.Index(i), reflect.Value{})
+	}
+
+	for i := common; i < b.Len(); i++ {
+		d.add(indexPath(path, i), ChangeAdded, reflect.Value{}, b.Index(i))
+	}
+}
+
+// diffMap - compare maps by keys.
+func (d *differ) diffMap(path string, a, b reflect.Value) {
+	keys := a.MapKeys()
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+
+	sortKeys(keys)
+
+	for _, key := range keys {
+		var (
+			keyPath = path + keySegment(key).String()
+			aElem   = a.MapIndex(key)
+			bElem   = b.MapIndex(key)
+		)
+
+		switch {
+		case !aElem.IsValid():
+			d.add(keyPath, ChangeAdded, reflect.Value{}, bElem)
+		case !bElem.IsValid():
+			d.add(keyPath, ChangeRemoved, aElem, reflect.Value{})
+		default:
+			d.diff(keyPath, aElem, bElem)
+		}
+	}
+}
+
+// hasExported - at least one field is exported.
+func hasExported(fields []fieldInfo) bool {
+	for i := range fields {
+		if fields[i].exported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// indexPath - path of slice element.
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// keySegment - path segment of map key.
+func keySegment(key reflect.Value) segment {
+	if key.Kind() == reflect.String {
+		return segment{key: key.String(), quoted: true}
+	}
+
+	return segment{key: fmt.Sprint(key.Interface())}
+}
+
+// sortKeys - sort map keys for stable order.
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		switch keys[i].Kind() {
+		case reflect.String:
+			return keys[i].String() < keys[j].String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return keys[i].Int() < keys[j].Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return keys[i].Uint() < keys[j].Uint()
+		case reflect.Float32, reflect.Float64:
+			return keys[i].Float() < keys[j].Float()
+		default:
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		}
+	})
+}
+
+// equalMethod - compare values by method `Equal(T) bool` (time.Time, ...). False if type has no such method.
+func equalMethod(a, b reflect.Value) (bool, bool) {
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+
+	mt := method.Type()
+	if mt.NumIn() != 1 || mt.In(0) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// floatEqual - compare floats of bits size, rounded to precision if WithFloatPrecision set,
+// with tolerances if set. NaNs are equal.
+func floatEqual(a, b float64, bits int, o *options) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+
+	if o.hasFloatPrec {
+		a, b = rmath.Round(a, o.floatPrec), rmath.Round(b, o.floatPrec)
+	}
+
+	return a == b || floatNear(a, b, bits, o)
+}
+
+// valueEqual - compare leaf values.
+func valueEqual(a, b reflect.Value) bool {
+	if a.Type().Comparable() {
+		return a.Equal(b)
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// Apply - apply changes found by Diff to struct by SetAttr.
+// Added slice elements are appended, removed ones truncate the slice, removed map keys are deleted,
+// removed pointers are set to nil.
+// 'obj': ptr struct.
+// 'opts': optional, same as for SetAttr.
+func Apply(obj interface{}, changes []Change, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opApply, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opApply, ErrNotStruct, objValue)
+	}
+
+	o := newOptions(opts)
+	for _, change := range changes {
+		if err := applyChange(objValue.Elem(), change, o); err != nil {
+			return opError(opApply, err)
+		}
+	}
+
+	return nil
+}
+
+// applyChange - apply one change to struct value.
+func applyChange(root reflect.Value, change Change, o *options) error {
+	segs, err := parsePath(change.Path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		last     = len(segs) - 1
+		newValue = reflect.ValueOf(change.New)
+		parent   reflect.Value
+	)
+	// parent container of slice element or map key
+	if segs[last].name == "" {
+		if parent, err = getPath(root, segs[:last], o); err != nil {
+			return err
+		}
+
+		for parent.Kind() == reflect.Ptr && !parent.IsNil() {
+			parent = parent.Elem()
+		}
+	}
+
+	switch {
+	case change.Kind == ChangeRemoved && parent.Kind() == reflect.Map:
+		key, err := mapKey(parent.Type().Key(), segs[last])
+		if err != nil {
+			return pathError(segs, last, err)
+		}
+		// map is a reference: delete from the original map
+		parent.SetMapIndex(key, reflect.Value{})
+
+		return nil
+	case change.Kind == ChangeRemoved && parent.Kind() == reflect.Slice:
+		idx, err := strconv.Atoi(segs[last].key)
+		if err != nil || idx < 0 {
+			return pathError(segs, last, ErrInvalidPath)
+		}
+		// removed elements are the tail of slice
+		if idx >= parent.Len() {
+			return nil
+		}
+
+		return setPath(root, segs[:last], parent.Slice(0, idx), o)
+	case change.Kind == ChangeAdded && parent.Kind() == reflect.Slice:
+		idx, err := strconv.Atoi(segs[last].key)
+		if err != nil || idx < 0 {
+			return pathError(segs, last, ErrInvalidPath)
+		}
+		// added elements are appended
+		if idx > parent.Len() {
+			return pathError(segs, last, ErrIndexOutOfRange)
+		}
+
+		if idx == parent.Len() {
+			elem, err := valueTo(newValue, parent.Type().Elem(), o)
+			if err != nil {
+				return pathError(segs, last, err)
+			}
+
+			return setPath(root, segs[:last], reflect.Append(parent, elem), o)
+		}
+	case !newValue.IsValid():
+		// removed ptr or nil interface: set zero value
+		field, err := getPath(root, segs, o)
+		if err != nil {
+			return err
+		}
+		newValue = reflect.Zero(field.Type())
+	}
+
+	return setPath(root, segs, newValue, o)
+}
@@ -0,0 +1,545 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type diffItem struct {
+	Name  string
+	Price float64
+}
+
+type diffOrder struct {
+	ID       int
+	Customer *diffCustomer
+	Items    []diffItem
+	Meta     map[string]string
+	Counts   map[int]int
+	Extra    interface{}
+	Created  time.Time
+	Coupon   *string
+	note     string
+}
+
+type diffCustomer struct {
+	Name string
+	Age  int
+}
+
+func TestDiff(t *testing.T) {
+	var (
+		coupon  = "coupon"
+		created = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		order   = diffOrder{
+			ID:       1,
+			Customer: &diffCustomer{Name: "name", Age: 30},
+			Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+			Meta:     map[string]string{"region": "eu", "source": "web"},
+			Counts:   map[int]int{1: 10},
+			Extra:    "extra",
+			Created:  created,
+			note:     "note",
+		}
+	)
+
+	testCases := []struct {
+		b           diffOrder
+		opts        []Option
+		expected    []Change
+		expectedErr error
+		testName    string
+	}{
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "new_note",
+			},
+			expected: nil,
+			testName: "OK. Equal structs, unexported field skipped",
+		},
+		{
+			b: diffOrder{
+				ID:       2,
+				Customer: &diffCustomer{Name: "name", Age: 31},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created.In(time.FixedZone("UTC+3", 3*60*60)),
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: "ID", Old: 1, New: 2, Kind: ChangeModified},
+				{Path: "Customer.Age", Old: 30, New: 31, Kind: ChangeModified},
+			},
+			testName: "OK. Nested ptr struct, time compared by Equal",
+		},
+		{
+			b: diffOrder{
+				ID:      1,
+				Items:   []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:    map[string]string{"region": "eu", "source": "web"},
+				Counts:  map[int]int{1: 10},
+				Extra:   "extra",
+				Created: created,
+				Coupon:  &coupon,
+				note:    "note",
+			},
+			expected: []Change{
+				{Path: "Customer", Old: diffCustomer{Name: "name", Age: 30}, Kind: ChangeRemoved},
+				{Path: "Coupon", New: "coupon", Kind: ChangeAdded},
+			},
+			testName: "OK. Ptr added and removed",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.75}, {Name: "b", Price: 2.5}, {Name: "c", Price: 3}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: "Items[0].Price", Old: 1.5, New: 1.75, Kind: ChangeModified},
+				{Path: "Items[2]", New: diffItem{Name: "c", Price: 3}, Kind: ChangeAdded},
+			},
+			testName: "OK. Slice element modified and added",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: "Items[1]", Old: diffItem{Name: "b", Price: 2.5}, Kind: ChangeRemoved},
+			},
+			testName: "OK. Slice element removed",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "us", "channel": "app"},
+				Counts:   map[int]int{1: 11},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: `Meta["channel"]`, New: "app", Kind: ChangeAdded},
+				{Path: `Meta["region"]`, Old: "eu", New: "us", Kind: ChangeModified},
+				{Path: `Meta["source"]`, Old: "web", Kind: ChangeRemoved},
+				{Path: "Counts[1]", Old: 10, New: 11, Kind: ChangeModified},
+			},
+			testName: "OK. Map keys added, modified and removed",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    1,
+				Created:  created,
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: "Extra", Old: "extra", New: 1, Kind: ChangeModified},
+			},
+			testName: "OK. Interface with another type",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5000001}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			expected: []Change{
+				{Path: "Items[0].Price", Old: 1.5, New: 1.5000001, Kind: ChangeModified},
+			},
+			testName: "OK. Float noise without precision",
+		},
+		{
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5000001}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			opts:     []Option{WithFloatPrecision(2)},
+			expected: nil,
+			testName: "OK. Float noise with precision",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Diff")
+			t.Description("Check func `Diff`")
+			t.WithParameters(
+				allure.NewParameter("opts", len(testCase.opts)),
+			)
+
+			actual, err := Diff(order, testCase.b, testCase.opts...)
+			if err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Diff error: %v", err))
+				return
+			}
+
+			t.Assert().Equal(testCase.expected, actual, "Check Diff")
+		})
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	testCases := []struct {
+		a, b        interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			a:           1,
+			b:           2,
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+		{
+			a:           diffItem{},
+			b:           diffCustomer{},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Different types",
+		},
+		{
+			a:           diffItem{},
+			b:           &diffItem{},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Struct and ptr struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Diff")
+			t.Description("Check func `Diff` errors")
+			t.WithParameters(
+				allure.NewParameter("a", testCase.a),
+				allure.NewParameter("b", testCase.b),
+			)
+
+			_, err := Diff(testCase.a, testCase.b)
+			t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Diff error: %v", err))
+		})
+	}
+}
+
+func TestDiffCycle(t *testing.T) {
+	type node struct {
+		Value float64
+		Next  *node
+	}
+
+	runner.Run(t, "OK. Equal cycles", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Diff")
+		t.Description("Check func `Diff` with equal ptr cycles")
+
+		a := &node{Value: 1}
+		a.Next = a
+		b := &node{Value: 1}
+		b.Next = b
+
+		actual, err := Diff(a, b)
+		t.Require().NoError(err)
+		t.Assert().Empty(actual)
+	})
+
+	runner.Run(t, "OK. Changes in cycles", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Diff")
+		t.Description("Check func `Diff` with different ptr cycles")
+
+		a := &node{Value: 1}
+		a.Next = &node{Value: 2, Next: a}
+		b := &node{Value: 1}
+		b.Next = &node{Value: 2.5, Next: b}
+
+		actual, err := Diff(a, b)
+		t.Require().NoError(err)
+		t.Assert().Equal([]Change{{Path: "Next.Value", Old: 2.0, New: 2.5, Kind: ChangeModified}}, actual)
+	})
+}
+
+func TestApply(t *testing.T) {
+	coupon := "coupon"
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		a, b     diffOrder
+		testName string
+	}{
+		{
+			a: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			b: diffOrder{
+				ID:       2,
+				Customer: &diffCustomer{Name: "name", Age: 31},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Created:  created,
+				note:     "note",
+			},
+			testName: "OK. Modified fields",
+		},
+		{
+			a: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			b: diffOrder{
+				ID:      1,
+				Items:   []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:    map[string]string{"region": "eu", "source": "web"},
+				Counts:  map[int]int{1: 10},
+				Extra:   "extra",
+				Created: created,
+				Coupon:  &coupon,
+				note:    "note",
+			},
+			testName: "OK. Ptr added and removed",
+		},
+		{
+			a: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}, {Name: "c"}, {Name: "d"}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			testName: "OK. Slice elements added",
+		},
+		{
+			a: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			testName: "OK. Slice elements removed",
+		},
+		{
+			a: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "eu", "source": "web"},
+				Counts:   map[int]int{1: 10},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			b: diffOrder{
+				ID:       1,
+				Customer: &diffCustomer{Name: "name", Age: 30},
+				Items:    []diffItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2.5}},
+				Meta:     map[string]string{"region": "us", "channel": "app"},
+				Counts:   map[int]int{2: 20},
+				Extra:    "extra",
+				Created:  created,
+				note:     "note",
+			},
+			testName: "OK. Map keys added, modified and removed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Apply")
+			t.Description("Check func `Apply` with changes from `Diff`")
+
+			changes, err := Diff(testCase.a, testCase.b)
+			t.Require().NoError(err)
+
+			err = Apply(&testCase.a, changes)
+			t.Require().NoError(err, "Apply error")
+
+			rest, err := Diff(testCase.a, testCase.b)
+			t.Require().NoError(err)
+			t.Assert().Empty(rest, "Check Apply")
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	testCases := []struct {
+		obj         interface{}
+		changes     []Change
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj:         diffOrder{},
+			expectedErr: ErrNotPointerStruct,
+			testName:    "ERR. Struct not by pointer",
+		},
+		{
+			obj:         &diffOrder{},
+			changes:     []Change{{Path: "ID", New: "1", Kind: ChangeModified}},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong value type",
+		},
+		{
+			obj:         &diffOrder{},
+			changes:     []Change{{Path: "Items[1]", New: diffItem{}, Kind: ChangeAdded}},
+			expectedErr: ErrIndexOutOfRange,
+			testName:    "ERR. Added slice element after the end",
+		},
+		{
+			obj:         &diffOrder{},
+			changes:     []Change{{Path: "Items[", Kind: ChangeRemoved}},
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Invalid path",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Apply")
+			t.Description("Check func `Apply` errors")
+			t.WithParameters(
+				allure.NewParameter("changes", testCase.changes),
+			)
+
+			err := Apply(testCase.obj, testCase.changes)
+			t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Apply error: %v", err))
+		})
+	}
+}
+
+func ExampleDiff() {
+	type Item struct {
+		Name  string
+		Price float64
+	}
+
+	type Order struct {
+		ID    int
+		Items []Item
+		Meta  map[string]string
+	}
+
+	before := Order{ID: 1, Items: []Item{{Name: "a", Price: 1.5}}, Meta: map[string]string{"region": "eu"}}
+	after := Order{ID: 1, Items: []Item{{Name: "a", Price: 1.5000001}, {Name: "b", Price: 2}}, Meta: map[string]string{}}
+
+	changes, err := Diff(before, after, WithFloatPrecision(2))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if err := Apply(&before, changes); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", before)
+	// Output:
+	// added Items[1]: <nil> -> {b 2}
+	// removed Meta["region"]: eu -> <nil>
+	// {ID:1 Items:[{Name:a Price:1.5} {Name:b Price:2}] Meta:map[]}
+}
@@ -0,0 +1,219 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// envTagKey - struct tag key of field environment variable like `env:"DB_HOST,required"`.
+const envTagKey = "env"
+
+// WithLookupEnv - FromEnv reads variables by lookup instead of os.LookupEnv, for tests.
+func WithLookupEnv(lookup func(name string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookupEnv = lookup
+	}
+}
+
+// FromEnv - set struct fields from environment variables. Variable name is prefix and field names in
+// upper snake case joined by `_` like `APP_DB_HOST`, tag `env:"NAME"` replaces name of the field.
+// Tag option `required` fails if variable is not set, tag `default:"..."` is used for zero field if
+// variable is not set. Empty variables are not set. Values are parsed like SetDefaults.
+// Walks into nested structs and struct ptrs, nil ptrs are allocated if any of their fields is set,
+// so required fields of nil struct ptrs are checked only then. Recursive nil ptrs are kept nil.
+// Untagged embedded structs do not add their name. Tag `env:"-"` and unexported fields are skipped.
+// 'obj': ptr struct.
+// 'prefix': variable name prefix like `APP`, may be empty.
+// 'opts': optional, WithLookupEnv.
+func FromEnv(obj interface{}, prefix string, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opFromEnv, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opFromEnv, ErrNotStruct, objValue)
+	}
+
+	// root ptr is on the path too, protects from cycles back to it
+	e := &envLoader{
+		lookup:  newOptions(opts).lookupEnv,
+		o:       &options{convert: true},
+		visited: make(map[reflect.Type]bool),
+		ptrs:    map[ptrKey]bool{{addr: objValue.Pointer(), typ: objValue.Type()}: true},
+	}
+	if e.lookup == nil {
+		e.lookup = os.LookupEnv
+	}
+
+	if _, err := e.load(objValue.Elem(), "", strings.TrimSuffix(prefix, "_")); err != nil {
+		return opError(opFromEnv, err)
+	}
+
+	return nil
+}
+
+// envLoader - sets struct fields from variables found by lookup.
+type envLoader struct {
+	lookup  func(name string) (string, bool)
+	o       *options              // conversion like SetAttr with WithConvert
+	visited map[reflect.Type]bool // structs on the current path, recursive nil ptrs are not allocated
+	ptrs    map[ptrKey]bool       // ptrs on the current path, protects from cycles
+}
+
+// load - set struct value fields recursively. Reports if any field is set.
+func (e *envLoader) load(v reflect.Value, path, prefix string) (bool, error) {
+	e.visited[v.Type()] = true
+	defer delete(e.visited, v.Type())
+
+	var set bool
+
+	fields := structFields(v.Type(), e.o)
+	for i := range fields {
+		f := &fields[i]
+		if !f.exported {
+			continue
+		}
+
+		tag := strings.Split(f.tag.Get(envTagKey), ",")
+		if tag[0] == "-" {
+			continue
+		}
+
+		required := false
+		for _, opt := range tag[1:] {
+			if opt != "required" {
+				err := fmt.Errorf("%w: env option %q", ErrInvalidTag, opt)
+				return set, &AttrError{Path: joinName(path, f.name), Err: err}
+			}
+			required = true
+		}
+
+		name := tag[0]
+		if name == "" && !v.Type().Field(f.index[0]).Anonymous {
+			name = envName(f.name)
+		}
+
+		ok, err := e.loadField(v.Field(f.index[0]), f, joinName(path, f.name), joinEnv(prefix, name), required)
+		if err != nil {
+			return set, err
+		}
+
+		set = set || ok
+	}
+
+	return set, nil
+}
+
+// loadField - set field from variable or nested struct fields from variables with name prefix.
+func (e *envLoader) loadField(field reflect.Value, f *fieldInfo, path, name string, required bool) (bool, error) {
+	// nested struct or struct ptr
+	if isEnvStruct(f.typ) {
+		if field.Kind() != reflect.Ptr {
+			return e.load(field, path, name)
+		}
+		// recursive nil ptr and cycle check
+		key := ptrKey{addr: field.Pointer(), typ: field.Type()}
+		if field.IsNil() && e.visited[f.typ.Elem()] || !field.IsNil() && e.ptrs[key] {
+			return false, nil
+		}
+
+		if !field.IsNil() {
+			e.ptrs[key] = true
+			defer delete(e.ptrs, key)
+
+			return e.load(field.Elem(), path, name)
+		}
+		// nil ptr is set only if any of its fields is set, required fields are checked then
+		nested := reflect.New(f.typ.Elem())
+
+		set, err := e.load(nested.Elem(), path, name)
+		if !set && errors.Is(err, ErrEnvNotSet) {
+			return false, nil
+		}
+
+		if set && err == nil {
+			field.Set(nested)
+		}
+
+		return set, err
+	}
+
+	value, ok := e.lookup(name)
+	if !ok || value == "" {
+		def, tagged := f.tag.Lookup(defaultTagKey)
+		switch {
+		case tagged && def != "":
+			// default is set for zero field only
+			if !field.IsZero() {
+				return false, nil
+			}
+			value = def
+		case required:
+			return false, &AttrError{Path: path, Err: fmt.Errorf("%w: %s", ErrEnvNotSet, name)}
+		default:
+			return false, nil
+		}
+	}
+
+	newValue, err := parseText(value, field.Type())
+	if err != nil {
+		return false, fieldError("", path, err)
+	}
+
+	if err := assign(field, newValue, e.o); err != nil {
+		return false, fieldError("", path, err)
+	}
+
+	return true, nil
+}
+
+// isEnvStruct - struct or struct ptr type with fields set from variables.
+// time.Time and encoding.TextUnmarshaler structs are parsed from a single variable.
+func isEnvStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// envName - field name in upper snake case: `DBHost` to `DB_HOST`.
+func envName(name string) string {
+	var (
+		b     strings.Builder
+		runes = []rune(name)
+	)
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// word start: `dbHost` or acronym end `DBHost`
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
+
+// joinEnv - variable name of prefix and name joined by `_`.
+func joinEnv(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case name == "":
+		return prefix
+	default:
+		return prefix + "_" + name
+	}
+}
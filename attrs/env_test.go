@@ -0,0 +1,274 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type EnvBase struct {
+	Debug bool
+}
+
+type envDB struct {
+	Host    string        `env:",required"`
+	Port    int           `default:"5432"`
+	Timeout time.Duration `env:"TTL"`
+}
+
+type envConfig struct {
+	EnvBase
+	Name     string `env:"SERVICE_NAME"`
+	HTTPPort uint16
+	Hosts    []string
+	Rate     *float64
+	Start    time.Time
+	DB       envDB
+	Replica  *envDB `env:"RO"`
+	Cache    *envDB `env:"-"`
+	secret   string
+}
+
+// envNode - struct with recursive ptr field.
+type envNode struct {
+	Val  int
+	Next *envNode
+}
+
+// envLookup - lookup of variables from map.
+func envLookup(env map[string]string) Option {
+	return WithLookupEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	rate := 0.5
+
+	testCases := []struct {
+		env         map[string]string
+		prefix      string
+		obj         *envConfig
+		expected    *envConfig
+		expectedErr error
+		testName    string
+	}{
+		{
+			env: map[string]string{
+				"APP_DEBUG":        "true",
+				"APP_SERVICE_NAME": "app",
+				"APP_HTTP_PORT":    "8080",
+				"APP_HOSTS":        "a, b",
+				"APP_RATE":         "0.5",
+				"APP_START":        "2024-01-02",
+				"APP_DB_HOST":      "localhost",
+				"APP_DB_TTL":       "5s",
+				"APP_RO_HOST":      "replica",
+				"APP_RO_PORT":      "5433",
+				"APP_CACHE_HOST":   "cache",
+				"APP_SECRET":       "secret",
+			},
+			prefix: "APP_",
+			obj:    &envConfig{},
+			expected: &envConfig{
+				EnvBase:  EnvBase{Debug: true},
+				Name:     "app",
+				HTTPPort: 8080,
+				Hosts:    []string{"a", "b"},
+				Rate:     &rate,
+				Start:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				DB:       envDB{Host: "localhost", Port: 5432, Timeout: 5 * time.Second},
+				Replica:  &envDB{Host: "replica", Port: 5433},
+			},
+			testName: "OK. All fields",
+		},
+		{
+			env: map[string]string{"DB_HOST": "localhost", "DB_PORT": "", "HTTP_PORT": ""},
+			obj: &envConfig{Name: "name", HTTPPort: 80, DB: envDB{Port: 1}},
+			expected: &envConfig{
+				Name:     "name",
+				HTTPPort: 80,
+				DB:       envDB{Host: "localhost", Port: 1},
+			},
+			testName: "OK. Empty prefix, values kept",
+		},
+		{
+			env:         map[string]string{},
+			prefix:      "APP",
+			obj:         &envConfig{},
+			expectedErr: ErrEnvNotSet,
+			testName:    "ERR. Required not set",
+		},
+		{
+			env:         map[string]string{"APP_DB_HOST": "localhost", "APP_HTTP_PORT": "70000"},
+			prefix:      "APP",
+			obj:         &envConfig{},
+			expectedErr: ErrValueOverflow,
+			testName:    "ERR. Overflow",
+		},
+		{
+			env:         map[string]string{"APP_DB_HOST": "localhost", "APP_DB_TTL": "5"},
+			prefix:      "APP",
+			obj:         &envConfig{},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Conversion",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("FromEnv")
+			t.Description("Check func `FromEnv`")
+			t.WithParameters(
+				allure.NewParameter("env", testCase.env),
+				allure.NewParameter("prefix", testCase.prefix),
+			)
+
+			err := FromEnv(testCase.obj, testCase.prefix, envLookup(testCase.env))
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("FromEnv error: %v", err))
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check FromEnv")
+		})
+	}
+}
+
+func TestFromEnvRecursive(t *testing.T) {
+	env := map[string]string{"APP_VAL": "1", "APP_NEXT_VAL": "2", "APP_NEXT_NEXT_VAL": "3"}
+
+	runner.Run(t, "OK. Recursive nil ptr not allocated", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromEnv")
+		t.Description("Check func `FromEnv` with recursive nil ptr field")
+		t.WithParameters(allure.NewParameter("env", env))
+
+		node := &envNode{}
+		t.Require().NoError(FromEnv(node, "APP", envLookup(env)))
+		t.Assert().Equal(&envNode{Val: 1}, node)
+	})
+
+	runner.Run(t, "OK. Recursive ptrs set", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromEnv")
+		t.Description("Check func `FromEnv` with recursive not nil ptr fields")
+		t.WithParameters(allure.NewParameter("env", env))
+
+		node := &envNode{Next: &envNode{}}
+		t.Require().NoError(FromEnv(node, "APP", envLookup(env)))
+		t.Assert().Equal(&envNode{Val: 1, Next: &envNode{Val: 2}}, node)
+	})
+
+	runner.Run(t, "OK. Ptr cycle", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromEnv")
+		t.Description("Check func `FromEnv` with ptr cycle")
+		t.WithParameters(allure.NewParameter("env", env))
+
+		node := &envNode{}
+		node.Next = &envNode{Next: node}
+
+		t.Require().NoError(FromEnv(node, "APP", envLookup(env)))
+		t.Assert().Equal(1, node.Val)
+		t.Assert().Equal(2, node.Next.Val)
+		t.Assert().Same(node, node.Next.Next)
+	})
+}
+
+func TestFromEnvErrors(t *testing.T) {
+	runner.Run(t, "ERR. Path in error", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromEnv")
+		t.Description("Check func `FromEnv` error path")
+
+		err := FromEnv(&envConfig{}, "APP", envLookup(nil))
+		t.Assert().EqualError(err, "FromEnv DB.Host: required environment variable not set: APP_DB_HOST")
+	})
+
+	runner.Run(t, "ERR. Invalid args", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromEnv")
+		t.Description("Check func `FromEnv` with invalid args")
+
+		t.Assert().ErrorIs(FromEnv(envConfig{}, ""), ErrNotPointerStruct)
+		t.Assert().ErrorIs(FromEnv(new(int), ""), ErrNotStruct)
+		t.Assert().ErrorIs(FromEnv(&struct {
+			Host string `env:"HOST,optional"`
+		}{}, ""), ErrInvalidTag)
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Host", expected: "HOST"},
+		{name: "MaxConns", expected: "MAX_CONNS"},
+		{name: "DBHost", expected: "DB_HOST"},
+		{name: "HTTPPort", expected: "HTTP_PORT"},
+		{name: "ID", expected: "ID"},
+		{name: "Port2Host", expected: "PORT2_HOST"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.name, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.name))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("envName")
+			t.Description("Check func `envName`")
+
+			t.Assert().Equal(testCase.expected, envName(testCase.name))
+		})
+	}
+}
+
+func ExampleFromEnv() {
+	type DB struct {
+		Host    string        `env:",required"`
+		Port    int           `default:"5432"`
+		Timeout time.Duration `env:"TTL"`
+	}
+
+	type Config struct {
+		Name  string `env:"SERVICE_NAME"`
+		Hosts []string
+		DB    *DB
+	}
+
+	env := map[string]string{
+		"APP_SERVICE_NAME": "app",
+		"APP_HOSTS":        "a,b",
+		"APP_DB_HOST":      "localhost",
+		"APP_DB_TTL":       "5s",
+	}
+
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	var cfg Config
+	if err := FromEnv(&cfg, "APP", WithLookupEnv(lookup)); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(cfg.Name, cfg.Hosts, *cfg.DB)
+	// Output: app [a b] {localhost 5432 5s}
+}
@@ -0,0 +1,412 @@
+package attrs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithAbsTolerance - Equal and Diff treat floats as equal if |a-b| <= tol.
+func WithAbsTolerance(tol float64) Option {
+	return func(o *options) {
+		o.absTol = tol
+	}
+}
+
+// WithRelTolerance - Equal and Diff treat floats as equal if |a-b| <= tol * max(|a|, |b|).
+func WithRelTolerance(tol float64) Option {
+	return func(o *options) {
+		o.relTol = tol
+	}
+}
+
+// WithULPTolerance - Equal and Diff treat floats as equal if there are at most ulps representable floats
+// of field type between them, so float32 fields are compared in float32 units.
+func WithULPTolerance(ulps uint64) Option {
+	return func(o *options) {
+		o.ulpTol = ulps
+	}
+}
+
+// WithIgnorePaths - Equal skips fields by paths like `Customer.UpdatedAt`, with nested fields.
+// Paths without indexes match all slice elements and map values: `Items.ID` matches `Items[0].ID`.
+func WithIgnorePaths(paths ...string) Option {
+	return func(o *options) {
+		if o.ignore == nil {
+			o.ignore = make(map[string]bool, len(paths))
+		}
+
+		for _, path := range paths {
+			o.ignore[path] = true
+		}
+	}
+}
+
+// WithNilEqualEmpty - Equal treats nil and empty slices and maps as equal.
+func WithNilEqualEmpty() Option {
+	return func(o *options) {
+		o.nilEmpty = true
+	}
+}
+
+// WithUnorderedSlices - Equal compares slices and arrays as multisets: each element must have
+// an equal pair at any index.
+func WithUnorderedSlices() Option {
+	return func(o *options) {
+		o.unordered = true
+	}
+}
+
+// WithAllDiffs - Equal reports all differences instead of the first one.
+func WithAllDiffs() Option {
+	return func(o *options) {
+		o.allDiffs = true
+	}
+}
+
+// Equal - deep equality of two values of the same type for tests, where reflect.DeepEqual fails
+// on float noise like 0.1+0.2 != 0.3. Recurses into nested structs, ptrs, interfaces, slices, arrays
+// and maps like Diff, types with method `Equal(T) bool` (time.Time, ...) are compared by it.
+// Unexported fields are skipped. Report lines look like `Items[0].Price: 1.5 != 2.5`,
+// missing slice elements and map keys are `<missing>`.
+// Floats are equal if they are equal after WithFloatPrecision rounding or within any of set tolerances.
+// 'a', 'b': values of the same type, usually structs or ptr structs.
+// 'opts': optional, WithAbsTolerance, WithRelTolerance, WithULPTolerance, WithFloatPrecision, WithIgnorePaths,
+// WithNilEqualEmpty, WithUnorderedSlices, WithAllDiffs, WithTagKey for tag names in paths.
+func Equal(a, b interface{}, opts ...Option) (bool, string) {
+	var (
+		aValue = reflect.ValueOf(a)
+		bValue = reflect.ValueOf(b)
+	)
+	// types check
+	if valueType(aValue) != valueType(bValue) {
+		return false, fmt.Sprintf("types differ: %v != %v", valueType(aValue), valueType(bValue))
+	}
+	// both nil check
+	if !aValue.IsValid() {
+		return true, ""
+	}
+
+	c := &comparer{o: newOptions(opts)}
+	c.compare("", aValue, bValue)
+
+	return len(c.diffs) == 0, strings.Join(c.diffs, "\n")
+}
+
+// comparer - collects differences while walking two values.
+type comparer struct {
+	o       *options
+	first   bool               // stop at the first difference regardless of WithAllDiffs
+	diffs   []string           // report lines
+	visited map[[2]ptrKey]bool // pointer pairs on the current path, protects from cycles
+}
+
+// done - no more differences are needed.
+func (c *comparer) done() bool {
+	return len(c.diffs) > 0 && (c.first || !c.o.allDiffs)
+}
+
+// add - add report line.
+func (c *comparer) add(path string, a, b reflect.Value) {
+	line := reportValue(a) + " != " + reportValue(b)
+	if path != "" {
+		line = path + ": " + line
+	}
+
+	c.diffs = append(c.diffs, line)
+}
+
+// ignored - path is ignored by WithIgnorePaths, full or without indexes.
+func (c *comparer) ignored(path string) bool {
+	return c.o.ignore[path] || c.o.ignore[stripIndexes(path)]
+}
+
+// compare - compare two values of the same type.
+func (c *comparer) compare(path string, a, b reflect.Value) {
+	if c.done() || c.ignored(path) {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		c.comparePtr(path, a, b)
+	case reflect.Interface:
+		switch {
+		case a.IsNil() || b.IsNil():
+			if a.IsNil() != b.IsNil() {
+				c.add(path, a, b)
+			}
+		case a.Elem().Type() != b.Elem().Type():
+			c.add(path, a, b)
+		default:
+			c.compare(path, a.Elem(), b.Elem())
+		}
+	case reflect.Struct:
+		if eq, ok := equalMethod(a, b); ok {
+			if !eq {
+				c.add(path, a, b)
+			}
+			return
+		}
+
+		c.compareStruct(path, a, b)
+	case reflect.Slice, reflect.Array:
+		switch {
+		case c.nilDiffers(a, b):
+			c.add(path, a, b)
+		case c.o.unordered:
+			c.compareUnordered(path, a, b)
+		default:
+			c.compareSlice(path, a, b)
+		}
+	case reflect.Map:
+		if c.nilDiffers(a, b) {
+			c.add(path, a, b)
+			return
+		}
+
+		c.compareMap(path, a, b)
+	case reflect.Float32, reflect.Float64:
+		if !floatEqual(a.Float(), b.Float(), a.Type().Bits(), c.o) {
+			c.add(path, a, b)
+		}
+	default:
+		if !valueEqual(a, b) {
+			c.add(path, a, b)
+		}
+	}
+}
+
+// comparePtr - compare pointers by pointed values.
+func (c *comparer) comparePtr(path string, a, b reflect.Value) {
+	// nil, the same ptr and cycle check
+	key := [2]ptrKey{{addr: a.Pointer(), typ: a.Type()}, {addr: b.Pointer(), typ: b.Type()}}
+
+	switch {
+	case a.IsNil() || b.IsNil():
+		if a.IsNil() != b.IsNil() {
+			c.add(path, a, b)
+		}
+	case a.Pointer() == b.Pointer() || c.visited[key]:
+	default:
+		if c.visited == nil {
+			c.visited = make(map[[2]ptrKey]bool)
+		}
+
+		c.visited[key] = true
+		defer delete(c.visited, key)
+
+		c.compare(path, a.Elem(), b.Elem())
+	}
+}
+
+// compareStruct - compare exported fields of two structs.
+func (c *comparer) compareStruct(path string, a, b reflect.Value) {
+	fields := structFields(a.Type(), c.o)
+	// struct without exported fields (time.Time, ...) is compared as a whole
+	if !hasExported(fields) {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			c.add(path, a, b)
+		}
+		return
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		if !f.exported {
+			continue
+		}
+
+		aField, aErr := fieldByInfo(a, f, false)
+		bField, bErr := fieldByInfo(b, f, false)
+		// nil embedded ptr
+		switch {
+		case aErr != nil && bErr != nil:
+		case aErr != nil || bErr != nil:
+			if !c.ignored(joinName(path, f.name)) {
+				c.add(joinName(path, f.name), aField, bField)
+			}
+		default:
+			c.compare(joinName(path, f.name), aField, bField)
+		}
+
+		if c.done() {
+			return
+		}
+	}
+}
+
+// compareSlice - compare slices or arrays element by element.
+func (c *comparer) compareSlice(path string, a, b reflect.Value) {
+	for i := 0; i < max(a.Len(), b.Len()) && !c.done(); i++ {
+		switch elemPath := indexPath(path, i); {
+		case i >= b.Len():
+			c.addElem(elemPath, a.Index(i), reflect.Value{})
+		case i >= a.Len():
+			c.addElem(elemPath, reflect.Value{}, b.Index(i))
+		default:
+			c.compare(elemPath, a.Index(i), b.Index(i))
+		}
+	}
+}
+
+// compareUnordered - compare slices or arrays as multisets. Elements of a are paired with the first
+// equal unpaired element of b, unpaired elements are reported by their indexes.
+func (c *comparer) compareUnordered(path string, a, b reflect.Value) {
+	paired := make([]bool, b.Len())
+
+	for i := 0; i < a.Len() && !c.done(); i++ {
+		elemPath := indexPath(path, i)
+		if j := c.pair(elemPath, a.Index(i), b, paired); j >= 0 {
+			paired[j] = true
+			continue
+		}
+
+		c.addElem(elemPath, a.Index(i), reflect.Value{})
+	}
+
+	for j := 0; j < b.Len() && !c.done(); j++ {
+		if !paired[j] {
+			c.addElem(indexPath(path, j), reflect.Value{}, b.Index(j))
+		}
+	}
+}
+
+// pair - index of the first unpaired element of b equal to elem, -1 if not found.
+func (c *comparer) pair(path string, elem, b reflect.Value, paired []bool) int {
+	for j := 0; j < b.Len(); j++ {
+		if paired[j] {
+			continue
+		}
+
+		sub := &comparer{o: c.o, first: true, visited: c.visited}
+		if sub.compare(path, elem, b.Index(j)); len(sub.diffs) == 0 {
+			return j
+		}
+	}
+
+	return -1
+}
+
+// compareMap - compare maps by keys.
+func (c *comparer) compareMap(path string, a, b reflect.Value) {
+	keys := a.MapKeys()
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+
+	sortKeys(keys)
+
+	for _, key := range keys {
+		var (
+			keyPath = path + keySegment(key).String()
+			aElem   = a.MapIndex(key)
+			bElem   = b.MapIndex(key)
+		)
+
+		if !aElem.IsValid() || !bElem.IsValid() {
+			c.addElem(keyPath, aElem, bElem)
+		} else {
+			c.compare(keyPath, aElem, bElem)
+		}
+
+		if c.done() {
+			return
+		}
+	}
+}
+
+// addElem - add report line of missing slice element or map key if its path is not ignored.
+func (c *comparer) addElem(path string, a, b reflect.Value) {
+	if !c.ignored(path) {
+		c.add(path, a, b)
+	}
+}
+
+// nilDiffers - one of empty slices or maps is nil and WithNilEqualEmpty is not set.
+func (c *comparer) nilDiffers(a, b reflect.Value) bool {
+	if c.o.nilEmpty || a.Kind() == reflect.Array {
+		return false
+	}
+
+	return a.IsNil() != b.IsNil() && a.Len() == 0 && b.Len() == 0
+}
+
+// reportValue - value in report: strings are quoted, nil values are `nil`, missing ones are `<missing>`.
+func reportValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<missing>"
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return "&" + reportValue(v.Elem())
+	case reflect.Interface:
+		return reportValue(v.Elem())
+	case reflect.String:
+		return strconv.Quote(v.String())
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// floatNear - floats are within absolute, relative or ULP tolerance.
+func floatNear(a, b float64, bits int, o *options) bool {
+	// infinities are equal only to themselves
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	diff := math.Abs(a - b)
+
+	switch {
+	case o.absTol > 0 && diff <= o.absTol:
+		return true
+	case o.relTol > 0 && diff <= o.relTol*math.Max(math.Abs(a), math.Abs(b)):
+		return true
+	case o.ulpTol > 0 && ulpDistance(a, b, bits) <= o.ulpTol:
+		return true
+	default:
+		return false
+	}
+}
+
+// ulpDistance - number of representable floats of bits size between a and b.
+func ulpDistance(a, b float64, bits int) uint64 {
+	ia, ib := ulpIndex(a, bits), ulpIndex(b, bits)
+	if ia < ib {
+		ia, ib = ib, ia
+	}
+
+	return uint64(ia) - uint64(ib)
+}
+
+// ulpIndex - float bits as integer ordered like floats: adjacent floats differ by 1, -0 and +0 are 0.
+func ulpIndex(f float64, bits int) int64 {
+	if bits == 32 {
+		i := int64(int32(math.Float32bits(float32(f))))
+		if i < 0 {
+			i = math.MinInt32 - i
+		}
+
+		return i
+	}
+
+	i := int64(math.Float64bits(f))
+	if i < 0 {
+		i = math.MinInt64 - i
+	}
+
+	return i
+}
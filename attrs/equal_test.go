@@ -0,0 +1,320 @@
+package attrs
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type equalLine struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+	Qty   float32 `json:"qty"`
+}
+
+type equalInvoice struct {
+	ID      int                `json:"id"`
+	Total   float64            `json:"total"`
+	Lines   []equalLine        `json:"lines"`
+	Tags    []string           `json:"tags"`
+	Rates   map[string]float64 `json:"rates"`
+	Payer   *equalLine         `json:"payer"`
+	Extra   interface{}        `json:"extra"`
+	Created time.Time          `json:"created"`
+	note    string
+}
+
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		base           func(inv *equalInvoice)
+		change         func(inv *equalInvoice)
+		opts           []Option
+		expected       bool
+		expectedReport string
+		testName       string
+	}{
+		{
+			change:   func(inv *equalInvoice) { inv.note = "other" },
+			expected: true,
+			testName: "OK. Equal, unexported fields skipped",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Total = 0.1 + inv.Lines[1].Price },
+			expectedReport: "Total: 0.30000000000000004 != 0.3",
+			testName:       "OK. Float noise",
+		},
+		{
+			change:   func(inv *equalInvoice) { inv.Total = 0.1 + inv.Lines[1].Price },
+			opts:     []Option{WithAbsTolerance(1e-9)},
+			expected: true,
+			testName: "OK. Abs tolerance",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Total = 0.31 },
+			opts:           []Option{WithAbsTolerance(1e-9)},
+			expectedReport: "Total: 0.31 != 0.3",
+			testName:       "OK. Out of abs tolerance",
+		},
+		{
+			change:   func(inv *equalInvoice) { inv.Total = 0.30003 },
+			opts:     []Option{WithRelTolerance(1e-3)},
+			expected: true,
+			testName: "OK. Rel tolerance",
+		},
+		{
+			change: func(inv *equalInvoice) {
+				inv.Total = math.Nextafter(0.3, 1)
+				inv.Lines[0].Qty = math.Nextafter32(1, 0)
+			},
+			opts:     []Option{WithULPTolerance(1)},
+			expected: true,
+			testName: "OK. ULP tolerance, float32 units",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Total = math.Nextafter(math.Nextafter(0.3, 1), 1) },
+			opts:           []Option{WithULPTolerance(1)},
+			expectedReport: "Total: 0.3000000000000001 != 0.3",
+			testName:       "OK. Out of ULP tolerance",
+		},
+		{
+			change:   func(inv *equalInvoice) { inv.Total = 0.3049 },
+			opts:     []Option{WithFloatPrecision(2)},
+			expected: true,
+			testName: "OK. Round precision",
+		},
+		{
+			change: func(inv *equalInvoice) {
+				inv.ID, inv.Lines[1].SKU, inv.Rates["eur"] = 2, "c", 0.9
+				inv.Lines = append(inv.Lines, equalLine{SKU: "d"})
+				inv.Payer, inv.Extra = &equalLine{SKU: "p"}, 1
+			},
+			opts: []Option{WithAllDiffs()},
+			expectedReport: `ID: 2 != 1
+Lines[1].SKU: "c" != "b"
+Lines[2]: {d 0 0} != <missing>
+Rates["eur"]: 0.9 != <missing>
+Payer: &{p 0 0} != nil
+Extra: 1 != "extra"`,
+			testName: "OK. All diffs",
+		},
+		{
+			change: func(inv *equalInvoice) {
+				inv.ID, inv.Lines[1].SKU, inv.Rates["eur"] = 2, "c", 0.9
+				inv.Created = time.Now()
+			},
+			opts:     []Option{WithIgnorePaths("ID", "Lines.SKU", "Rates", "Created")},
+			expected: true,
+			testName: "OK. Ignore paths",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Lines[0].SKU, inv.Lines[1].SKU = "c", "d" },
+			opts:           []Option{WithIgnorePaths("lines[0]"), WithTagKey("json")},
+			expectedReport: `lines[1].sku: "d" != "b"`,
+			testName:       "OK. Ignore path with index, tag names",
+		},
+		{
+			base:           func(inv *equalInvoice) { inv.Tags, inv.Rates = []string{}, nil },
+			change:         func(inv *equalInvoice) { inv.Tags, inv.Rates = nil, map[string]float64{} },
+			opts:           []Option{WithAllDiffs()},
+			expectedReport: "Tags: nil != []\nRates: map[] != nil",
+			testName:       "OK. Nil and empty",
+		},
+		{
+			base:     func(inv *equalInvoice) { inv.Tags, inv.Rates = []string{}, nil },
+			change:   func(inv *equalInvoice) { inv.Tags, inv.Rates = nil, map[string]float64{} },
+			opts:     []Option{WithNilEqualEmpty()},
+			expected: true,
+			testName: "OK. Nil equal empty",
+		},
+		{
+			change: func(inv *equalInvoice) {
+				inv.Lines[0], inv.Lines[1] = inv.Lines[1], inv.Lines[0]
+				inv.Tags = []string{"y", "x"}
+			},
+			opts:     []Option{WithUnorderedSlices()},
+			expected: true,
+			testName: "OK. Unordered slices",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Tags = []string{"y", "z", "y"} },
+			opts:           []Option{WithUnorderedSlices(), WithAllDiffs()},
+			expectedReport: "Tags[1]: \"z\" != <missing>\nTags[2]: \"y\" != <missing>\nTags[0]: <missing> != \"x\"",
+			testName:       "OK. Unordered slices differ",
+		},
+		{
+			change:         func(inv *equalInvoice) { inv.Created = inv.Created.Add(time.Second) },
+			expectedReport: "Created: 2024-01-01 00:00:01 +0000 UTC != 2024-01-01 00:00:00 +0000 UTC",
+			testName:       "OK. Equal method",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Equal")
+			t.Description("Check func `Equal`")
+			t.WithParameters(allure.NewParameter("opts", len(testCase.opts)))
+
+			var (
+				expected = equalInvoice{
+					ID:      1,
+					Total:   0.3,
+					Lines:   []equalLine{{SKU: "a", Price: 0.1, Qty: 1}, {SKU: "b", Price: 0.2, Qty: 2}},
+					Tags:    []string{"x", "y"},
+					Rates:   map[string]float64{"usd": 1.1},
+					Extra:   "extra",
+					Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					note:    "note",
+				}
+				actual = equalInvoice{
+					ID:      1,
+					Total:   0.3,
+					Lines:   []equalLine{{SKU: "a", Price: 0.1, Qty: 1}, {SKU: "b", Price: 0.2, Qty: 2}},
+					Tags:    []string{"x", "y"},
+					Rates:   map[string]float64{"usd": 1.1},
+					Extra:   "extra",
+					Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					note:    "note",
+				}
+			)
+
+			if testCase.base != nil {
+				testCase.base(&expected)
+				testCase.base(&actual)
+			}
+
+			testCase.change(&actual)
+
+			ok, report := Equal(&actual, &expected, testCase.opts...)
+			t.Assert().Equal(testCase.expected, ok, "Check equal")
+			t.Assert().Equal(testCase.expectedReport, report, "Check report")
+		})
+	}
+
+	runner.Run(t, "OK. Not structs", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Equal")
+		t.Description("Check func `Equal` with not struct values")
+
+		ok, report := Equal(1, "1")
+		t.Assert().False(ok)
+		t.Assert().Equal("types differ: int != string", report)
+
+		ok, report = Equal([]float64{0.1 + 0.2}, []float64{0.3}, WithFloatPrecision(10))
+		t.Assert().True(ok)
+		t.Assert().Empty(report)
+
+		ok, report = Equal(math.NaN(), math.NaN())
+		t.Assert().True(ok, report)
+
+		ok, _ = Equal(math.Inf(1), math.MaxFloat64, WithRelTolerance(1))
+		t.Assert().False(ok)
+
+		ok, _ = Equal(nil, nil)
+		t.Assert().True(ok)
+	})
+
+	runner.Run(t, "OK. Cycle", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Equal")
+		t.Description("Check func `Equal` with ptr cycle")
+
+		type node struct {
+			Value float64
+			Next  *node
+		}
+
+		a := &node{Value: 1}
+		a.Next = &node{Value: 2, Next: a}
+		b := &node{Value: 1}
+		b.Next = &node{Value: 2.5, Next: b}
+
+		ok, report := Equal(a, b)
+		t.Assert().False(ok)
+		t.Assert().Equal("Next.Value: 2 != 2.5", report)
+	})
+}
+
+func TestDiffTolerance(t *testing.T) {
+	runner.Run(t, "OK. Diff with tolerance", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Diff")
+		t.Description("Check func `Diff` with float tolerance")
+
+		var (
+			a = equalInvoice{
+				ID:      1,
+				Total:   0.3,
+				Lines:   []equalLine{{SKU: "a", Price: 0.1, Qty: 1}, {SKU: "b", Price: 0.2, Qty: 2}},
+				Tags:    []string{"x", "y"},
+				Rates:   map[string]float64{"usd": 1.1},
+				Extra:   "extra",
+				Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				note:    "note",
+			}
+			// float noise of 0.1 + 0.2 in total
+			b = equalInvoice{
+				ID:      1,
+				Total:   0.30000000000000004,
+				Lines:   []equalLine{{SKU: "a", Price: 0.2, Qty: 1}, {SKU: "b", Price: 0.2, Qty: 2}},
+				Tags:    []string{"x", "y"},
+				Rates:   map[string]float64{"usd": 1.1},
+				Extra:   "extra",
+				Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				note:    "note",
+			}
+		)
+
+		changes, err := Diff(a, b, WithAbsTolerance(1e-9))
+		t.Require().NoError(err)
+		t.Assert().Equal([]Change{{Path: "Lines[0].Price", Old: 0.1, New: 0.2, Kind: ChangeModified}}, changes)
+	})
+}
+
+func ExampleEqual() {
+	type Line struct {
+		SKU   string
+		Price float64
+	}
+
+	type Invoice struct {
+		ID      int
+		Total   float64
+		Lines   []Line
+		Created time.Time
+	}
+
+	expected := Invoice{ID: 1, Total: 0.3, Lines: []Line{{SKU: "a", Price: 0.1}, {SKU: "b", Price: 0.2}}}
+	actual := Invoice{
+		ID:      2,
+		Total:   0.1 + expected.Lines[1].Price,
+		Lines:   []Line{{SKU: "b", Price: 0.2}, {SKU: "a", Price: 0.1}},
+		Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	ok, report := Equal(actual, expected, WithAllDiffs())
+	fmt.Println(ok)
+	fmt.Println(report)
+
+	ok, _ = Equal(actual, expected, WithAbsTolerance(1e-9), WithUnorderedSlices(), WithIgnorePaths("ID", "Created"))
+	fmt.Println(ok)
+	// Output:
+	// false
+	// ID: 2 != 1
+	// Total: 0.30000000000000004 != 0.3
+	// Lines[0].SKU: "b" != "a"
+	// Lines[0].Price: 0.2 != 0.1
+	// Lines[1].SKU: "a" != "b"
+	// Lines[1].Price: 0.1 != 0.2
+	// Created: 2024-01-01 00:00:00 +0000 UTC != 0001-01-01 00:00:00 +0000 UTC
+	// true
+}
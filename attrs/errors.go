@@ -0,0 +1,180 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors. Every error returned by the package wraps one of them, check it with errors.Is.
+var (
+	ErrNotStruct           = errors.New("not a struct")
+	ErrFieldNotInStruct    = errors.New("field not in struct")
+	ErrUnexportedField     = errors.New("field not exported")
+	ErrWrongFieldValueType = errors.New("wrong field value type")
+	ErrNotPointerStruct    = errors.New("struct passed not by pointer")
+	ErrPointerStruct       = errors.New("struct passed by pointer")
+	ErrInvalidPath         = errors.New("invalid field path")
+	ErrNilPointer          = errors.New("nil pointer in field path")
+	ErrIndexOutOfRange     = errors.New("index out of range")
+	ErrKeyNotInMap         = errors.New("key not in map")
+	ErrNotIndexable        = errors.New("field not indexable")
+	ErrConversion          = errors.New("value conversion failed")
+	ErrValueOverflow       = errors.New("value overflows field type")
+	ErrInvalidTag          = errors.New("invalid struct tag")
+	ErrInvalidOption       = errors.New("invalid option")
+	ErrEnvNotSet           = errors.New("required environment variable not set")
+	ErrInvalidPatch        = errors.New("invalid patch document")
+	ErrPatchTestFailed     = errors.New("patch test failed")
+	ErrNotOrdered          = errors.New("field type not ordered")
+	ErrInvalidCSV          = errors.New("invalid CSV")
+)
+
+// operation names for AttrError.
+const (
+	opGetAttr                = "GetAttr"
+	opSetAttr                = "SetAttr"
+	opSetStructAttrs         = "SetStructAttrs"
+	opRoundStructFloatFields = "RoundStructFloatFields"
+	opDiff                   = "Diff"
+	opApply                  = "Apply"
+	opToMap                  = "ToMap"
+	opFromMap                = "FromMap"
+	opGetAs                  = "GetAs"
+	opNewField               = "NewField"
+	opWalk                   = "Walk"
+	opSetDefaults            = "SetDefaults"
+	opValidate               = "Validate"
+	opRedact                 = "Redact"
+	opFromEnv                = "FromEnv"
+	opProject                = "Project"
+	opMaskedCopy             = "MaskedCopy"
+	opApplyJSONPatch         = "ApplyJSONPatch"
+	opApplyMergePatch        = "ApplyMergePatch"
+	opPluck                  = "Pluck"
+	opSetAll                 = "SetAll"
+	opGroupBy                = "GroupBy"
+	opSortBy                 = "SortBy"
+	opRoundAll               = "RoundAll"
+	opWriteCSV               = "WriteCSV"
+	opReadCSV                = "ReadCSV"
+	opTrack                  = "Track"
+	opFlatten                = "Flatten"
+	opUnflatten              = "Unflatten"
+)
+
+// AttrError - error of operation on struct field. Unwraps to one of sentinel errors.
+type AttrError struct {
+	Op       string       // func name: GetAttr, SetAttr, ...
+	Path     string       // field path, empty if error is not about field
+	Expected reflect.Type // expected field type, nil if not known
+	Actual   reflect.Type // actual value type, nil if not known
+	Err      error        // sentinel error
+}
+
+// Error - error message like `SetAttr Customer.Age: wrong field value type (expected int, got string)`.
+func (e *AttrError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.Op)
+
+	if e.Path != "" {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(e.Path)
+	}
+
+	if b.Len() > 0 {
+		b.WriteString(": ")
+	}
+
+	b.WriteString(e.Err.Error())
+
+	switch {
+	case e.Expected != nil:
+		fmt.Fprintf(&b, " (expected %s, got %s)", e.Expected, typeName(e.Actual))
+	case e.Actual != nil:
+		fmt.Fprintf(&b, " (got %s)", e.Actual)
+	}
+
+	return b.String()
+}
+
+// Unwrap - sentinel error for errors.Is.
+func (e *AttrError) Unwrap() error {
+	return e.Err
+}
+
+// typeName - type name, 'nil' for nil type.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+
+	return t.String()
+}
+
+// opError - set operation name on AttrError, or wrap sentinel err in new one.
+func opError(op string, err error) error {
+	var attrErr *AttrError
+	if errors.As(err, &attrErr) {
+		attrErr.Op = op
+		return attrErr
+	}
+
+	return &AttrError{Op: op, Err: err}
+}
+
+// fieldError - set operation name and field path on AttrError, or wrap sentinel err in new one.
+func fieldError(op, path string, err error) error {
+	var attrErr *AttrError
+	if !errors.As(err, &attrErr) {
+		attrErr = &AttrError{Err: err}
+	}
+
+	attrErr.Op = op
+	attrErr.Path = path
+
+	return attrErr
+}
+
+// prefixError - prepend parent field path to AttrError path.
+func prefixError(err error, prefix string) error {
+	var attrErr *AttrError
+	if !errors.As(err, &attrErr) {
+		return &AttrError{Path: prefix, Err: err}
+	}
+
+	switch {
+	case attrErr.Path == "":
+		attrErr.Path = prefix
+	case attrErr.Path[0] == '[':
+		attrErr.Path = prefix + attrErr.Path
+	default:
+		attrErr.Path = prefix + "." + attrErr.Path
+	}
+
+	return attrErr
+}
+
+// objError - AttrError for wrong object passed to op.
+func objError(op string, err error, obj reflect.Value) error {
+	return &AttrError{Op: op, Err: err, Actual: valueType(obj)}
+}
+
+// typeError - AttrError for wrong value type.
+func typeError(err error, expected, actual reflect.Type) *AttrError {
+	return &AttrError{Err: err, Expected: expected, Actual: actual}
+}
+
+// valueType - type of value, nil for invalid value.
+func valueType(v reflect.Value) reflect.Type {
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Type()
+}
@@ -0,0 +1,151 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+func TestAttrError(t *testing.T) {
+	type User struct {
+		Username string
+		Age      int
+	}
+
+	type NewUser struct {
+		Age string
+	}
+
+	testCases := []struct {
+		call        func() error
+		expected    *AttrError
+		expectedMsg string
+		testName    string
+	}{
+		{
+			call: func() error {
+				return SetAttr(&User{}, "30", "Age")
+			},
+			expected: &AttrError{
+				Op:       opSetAttr,
+				Path:     "Age",
+				Expected: reflect.TypeOf(0),
+				Actual:   reflect.TypeOf(""),
+				Err:      ErrWrongFieldValueType,
+			},
+			expectedMsg: "SetAttr Age: wrong field value type (expected int, got string)",
+			testName:    "OK. SetAttr wrong field value type",
+		},
+		{
+			call: func() error {
+				return SetAttr(&User{}, nil, "Age")
+			},
+			expected: &AttrError{
+				Op:       opSetAttr,
+				Path:     "Age",
+				Expected: reflect.TypeOf(0),
+				Err:      ErrWrongFieldValueType,
+			},
+			expectedMsg: "SetAttr Age: wrong field value type (expected int, got nil)",
+			testName:    "OK. SetAttr nil value",
+		},
+		{
+			call: func() error {
+				_, err := GetAttr(User{}, "Address.City")
+				return err
+			},
+			expected: &AttrError{
+				Op:   opGetAttr,
+				Path: "Address",
+				Err:  ErrFieldNotInStruct,
+			},
+			expectedMsg: "GetAttr Address: field not in struct",
+			testName:    "OK. GetAttr field not in struct",
+		},
+		{
+			call: func() error {
+				_, err := GetAttr(&User{}, "Age")
+				return err
+			},
+			expected: &AttrError{
+				Op:     opGetAttr,
+				Actual: reflect.TypeOf(&User{}),
+				Err:    ErrPointerStruct,
+			},
+			expectedMsg: "GetAttr: struct passed by pointer (got *attrs.User)",
+			testName:    "OK. GetAttr struct passed by pointer",
+		},
+		{
+			call: func() error {
+				return SetStructAttrs(&User{}, NewUser{Age: "30"})
+			},
+			expected: &AttrError{
+				Op:       opSetStructAttrs,
+				Path:     "Age",
+				Expected: reflect.TypeOf(0),
+				Actual:   reflect.TypeOf(""),
+				Err:      ErrWrongFieldValueType,
+			},
+			expectedMsg: "SetStructAttrs Age: wrong field value type (expected int, got string)",
+			testName:    "OK. SetStructAttrs wrong field value type",
+		},
+		{
+			call: func() error {
+				return SetAttr(&User{}, "name", "Username[")
+			},
+			expected: &AttrError{
+				Op:   opSetAttr,
+				Path: "Username[",
+				Err:  ErrInvalidPath,
+			},
+			expectedMsg: "SetAttr Username[: invalid field path",
+			testName:    "OK. SetAttr invalid path",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("AttrError")
+			t.Description("Check type `AttrError`")
+			t.WithParameters(
+				allure.NewParameter("expected", testCase.expectedMsg),
+			)
+
+			err := testCase.call()
+
+			var attrErr *AttrError
+			t.Require().True(errors.As(err, &attrErr), "Check errors.As")
+			t.Assert().ErrorIs(err, testCase.expected.Err, "Check errors.Is")
+			t.Assert().Equal(testCase.expected, attrErr, "Check AttrError")
+			t.Assert().EqualError(err, testCase.expectedMsg, "Check AttrError message")
+		})
+	}
+}
+
+func ExampleAttrError() {
+	type User struct {
+		Age int
+	}
+
+	err := SetAttr(&User{}, "30", "Age")
+
+	var attrErr *AttrError
+	if errors.As(err, &attrErr) {
+		fmt.Println(attrErr.Op, attrErr.Path, attrErr.Expected, attrErr.Actual)
+	}
+
+	fmt.Println(errors.Is(err, ErrWrongFieldValueType))
+	// Output:
+	// SetAttr Age int string
+	// true
+}
@@ -0,0 +1,223 @@
+package attrs
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// GetAs - get struct field value as type T. Unlike GetAttr ptr fields are not dereferenced silently:
+// field of type *T is returned as T, ErrNilPointer if nil.
+// 'obj': struct or ptr struct.
+// 'path': field name or path like `Customer.Address.City`, `Items[2].Price`, `Meta["region"]`.
+// 'opts': optional, WithConvert to convert field value to T, WithTagKey to resolve names by struct tag.
+func GetAs[T any](obj interface{}, path string, opts ...Option) (T, error) {
+	var zero T
+
+	objValue := reflect.ValueOf(obj)
+	// ptr struct check
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		objValue = objValue.Elem()
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return zero, objError(opGetAs, ErrNotStruct, objValue)
+	}
+
+	o := newOptions(opts)
+
+	field, err := getField(objValue, path, o)
+	if err != nil {
+		return zero, opError(opGetAs, err)
+	}
+
+	out, err := valueAs[T](field, o)
+	if err != nil {
+		return zero, fieldError(opGetAs, path, err)
+	}
+
+	return out, nil
+}
+
+// valueAs - value as type T: the same type, dynamic value of interface, interface implemented by value,
+// dereferenced ptr or converted value.
+func valueAs[T any](v reflect.Value, o *options) (T, error) {
+	var (
+		zero   T
+		target = reflect.TypeFor[T]()
+	)
+
+	switch {
+	case v.Type() == target:
+		// addressable value is read without boxing
+		if v.CanAddr() {
+			return *v.Addr().Interface().(*T), nil
+		}
+
+		return v.Interface().(T), nil
+	case v.Kind() == reflect.Interface:
+		// interface field: its dynamic value
+		if v.IsNil() {
+			if target.Kind() == reflect.Interface {
+				return zero, nil
+			}
+
+			return zero, typeError(ErrWrongFieldValueType, target, nil)
+		}
+
+		return valueAs[T](v.Elem(), o)
+	case target.Kind() == reflect.Interface && v.Type().Implements(target):
+		return v.Interface().(T), nil
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == target:
+		if v.IsNil() {
+			return zero, ErrNilPointer
+		}
+
+		return *v.Interface().(*T), nil
+	case o.convert:
+		converted, err := convertValue(v, target)
+		if err != nil {
+			return zero, err
+		}
+
+		return converted.Interface().(T), nil
+	default:
+		return zero, typeError(ErrWrongFieldValueType, target, v.Type())
+	}
+}
+
+// Field - precompiled handle of struct S field of type T. Reads and writes the field on *S
+// by memory offsets without per-call name lookup, safe for concurrent use.
+type Field[S any, T any] struct {
+	path    string         // field name or dotted path
+	offsets []uintptr      // field offset from struct start or from previous ptr target
+	derefs  []reflect.Type // types of ptr targets between offsets, len(offsets)-1
+}
+
+// NewField - handle of struct S field of type T.
+// 'path': field name or dotted path through nested structs and struct ptrs like `Customer.Address.City`,
+// indexes and map keys are not supported.
+// 'opts': optional, WithTagKey to resolve names by struct tag.
+func NewField[S any, T any](path string, opts ...Option) (*Field[S, T], error) {
+	t := reflect.TypeFor[S]()
+	// is struct check
+	if t.Kind() != reflect.Struct {
+		return nil, &AttrError{Op: opNewField, Err: ErrNotStruct, Actual: t}
+	}
+
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, opError(opNewField, err)
+	}
+
+	var (
+		o      = newOptions(opts)
+		f      = &Field[S, T]{path: path}
+		offset uintptr
+	)
+
+	for i, seg := range segs {
+		// field names only
+		if seg.name == "" {
+			return nil, fieldError(opNewField, joinPath(segs[:i+1]), ErrInvalidPath)
+		}
+		// dereference struct ptr between segments
+		if i > 0 && t.Kind() == reflect.Ptr {
+			f.offsets = append(f.offsets, offset)
+			f.derefs = append(f.derefs, t.Elem())
+			t, offset = t.Elem(), 0
+		}
+		// is struct check
+		if t.Kind() != reflect.Struct {
+			return nil, fieldError(opNewField, joinPath(segs[:i+1]), ErrNotStruct)
+		}
+
+		info, ok := lookupField(t, seg.name, o)
+		if !ok {
+			return nil, fieldError(opNewField, joinPath(segs[:i+1]), ErrFieldNotInStruct)
+		}
+
+		if !info.exported {
+			return nil, fieldError(opNewField, joinPath(segs[:i+1]), ErrUnexportedField)
+		}
+		// walk through embedded structs and ptrs
+		for j, x := range info.index {
+			if j > 0 && t.Kind() == reflect.Ptr {
+				f.offsets = append(f.offsets, offset)
+				f.derefs = append(f.derefs, t.Elem())
+				t, offset = t.Elem(), 0
+			}
+
+			sf := t.Field(x)
+			offset += sf.Offset
+			t = sf.Type
+		}
+	}
+	// field type check
+	if target := reflect.TypeFor[T](); t != target {
+		return nil, fieldError(opNewField, path, typeError(ErrWrongFieldValueType, t, target))
+	}
+
+	f.offsets = append(f.offsets, offset)
+
+	return f, nil
+}
+
+// MustField - like NewField but panics on error. For package level handles.
+func MustField[S any, T any](path string, opts ...Option) *Field[S, T] {
+	f, err := NewField[S, T](path, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// Path - field name or path of handle.
+func (f *Field[S, T]) Path() string {
+	return f.path
+}
+
+// Ptr - ptr to field of obj, nil if path goes through nil ptr.
+func (f *Field[S, T]) Ptr(obj *S) *T {
+	p := unsafe.Pointer(obj)
+
+	for i := range f.derefs {
+		p = *(*unsafe.Pointer)(unsafe.Add(p, f.offsets[i]))
+		if p == nil {
+			return nil
+		}
+	}
+
+	return (*T)(unsafe.Add(p, f.offsets[len(f.offsets)-1]))
+}
+
+// Get - field value of obj, zero value if path goes through nil ptr.
+func (f *Field[S, T]) Get(obj *S) T {
+	// fast path: no ptrs in path
+	if len(f.derefs) == 0 {
+		return *(*T)(unsafe.Add(unsafe.Pointer(obj), f.offsets[0]))
+	}
+
+	if p := f.Ptr(obj); p != nil {
+		return *p
+	}
+
+	var zero T
+
+	return zero
+}
+
+// Set - set field value of obj, nil ptrs in path are allocated.
+func (f *Field[S, T]) Set(obj *S, value T) {
+	p := unsafe.Pointer(obj)
+
+	for i, t := range f.derefs {
+		slot := (*unsafe.Pointer)(unsafe.Add(p, f.offsets[i]))
+		if *slot == nil {
+			*slot = reflect.New(t).UnsafePointer()
+		}
+		p = *slot
+	}
+
+	*(*T)(unsafe.Add(p, f.offsets[len(f.offsets)-1])) = value
+}
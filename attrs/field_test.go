@@ -0,0 +1,349 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type fieldAddress struct {
+	City string `json:"city"`
+}
+
+type FieldBase struct {
+	ID int
+}
+
+type fieldUser struct {
+	*FieldBase
+	Name    string        `json:"name"`
+	Age     *int          `json:"age"`
+	Address fieldAddress  `json:"address"`
+	Billing *fieldAddress `json:"billing"`
+	Scores  []float64     `json:"scores"`
+	Any     interface{}   `json:"any"`
+	secret  string
+}
+
+func TestGetAs(t *testing.T) {
+	age := 30
+	user := fieldUser{
+		Name:    "name",
+		Age:     &age,
+		Address: fieldAddress{City: "city"},
+		Scores:  []float64{1.5},
+		Any:     fmt.Stringer(nil),
+	}
+
+	testCases := []struct {
+		get         func(obj interface{}) (interface{}, error)
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[string](obj, "Name") },
+			expected: "name",
+			testName: "OK. Same type",
+		},
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[int](obj, "Age") },
+			expected: 30,
+			testName: "OK. Ptr field dereferenced",
+		},
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[*int](obj, "Age") },
+			expected: &age,
+			testName: "OK. Ptr field as ptr",
+		},
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[float64](obj, "Scores[0]") },
+			expected: 1.5,
+			testName: "OK. Path",
+		},
+		{
+			get: func(obj interface{}) (interface{}, error) {
+				return GetAs[string](obj, "address.city", WithTagKey("json"))
+			},
+			expected: "city",
+			testName: "OK. Tag names",
+		},
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[int64](obj, "Age", WithConvert()) },
+			expected: int64(30),
+			testName: "OK. Conversion",
+		},
+		{
+			get:      func(obj interface{}) (interface{}, error) { return GetAs[fmt.Stringer](obj, "Any") },
+			expected: nil,
+			testName: "OK. Nil interface",
+		},
+		{
+			get:         func(obj interface{}) (interface{}, error) { return GetAs[int64](obj, "Age") },
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong type",
+		},
+		{
+			get:         func(obj interface{}) (interface{}, error) { return GetAs[fieldAddress](obj, "Billing") },
+			expectedErr: ErrNilPointer,
+			testName:    "ERR. Nil ptr field",
+		},
+		{
+			get:         func(obj interface{}) (interface{}, error) { return GetAs[string](obj, "Unknown") },
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			get:         func(interface{}) (interface{}, error) { return GetAs[string](1, "Name") },
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("GetAs")
+			t.Description("Check func `GetAs`")
+
+			for _, obj := range []interface{}{user, &user} {
+				actual, err := testCase.get(obj)
+				if err != nil {
+					t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("GetAs error: %v", err))
+					continue
+				}
+
+				if testCase.expected == nil {
+					t.Assert().Nil(actual, "Check GetAs")
+					continue
+				}
+
+				t.Assert().Equal(testCase.expected, actual, "Check GetAs")
+			}
+		})
+	}
+}
+
+func TestNewField(t *testing.T) {
+	testCases := []struct {
+		newField    func() error
+		expectedErr error
+		testName    string
+	}{
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, string]("Billing.City")
+				return err
+			},
+			testName: "OK. Path through ptr",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, string]("billing.city", WithTagKey("json"))
+				return err
+			},
+			testName: "OK. Tag names",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, int]("Name")
+				return err
+			},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong field type",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, float64]("Scores[0]")
+				return err
+			},
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Index in path",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, string]("secret")
+				return err
+			},
+			expectedErr: ErrUnexportedField,
+			testName:    "ERR. Unexported field",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[fieldUser, string]("Name.First")
+				return err
+			},
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct in path",
+		},
+		{
+			newField: func() error {
+				_, err := NewField[int, int]("Name")
+				return err
+			},
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("NewField")
+			t.Description("Check func `NewField`")
+
+			err := testCase.newField()
+			if testCase.expectedErr == nil {
+				t.Assert().NoError(err)
+				return
+			}
+
+			t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("NewField error: %v", err))
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	runner.Run(t, "OK. Get and Set", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Field")
+		t.Description("Check `Field` Get, Set and Ptr")
+		t.WithParameters(
+			allure.NewParameter("paths", []string{"Name", "Address.City", "Billing.City", "ID"}),
+		)
+
+		var (
+			name    = MustField[fieldUser, string]("Name")
+			city    = MustField[fieldUser, string]("Address.City")
+			billing = MustField[fieldUser, string]("Billing.City")
+			id      = MustField[fieldUser, int]("ID")
+			user    = &fieldUser{}
+		)
+		// nil ptrs in path
+		t.Assert().Equal("", billing.Get(user))
+		t.Assert().Nil(billing.Ptr(user))
+		t.Assert().Equal(0, id.Get(user))
+
+		name.Set(user, "name")
+		city.Set(user, "city")
+		billing.Set(user, "billing_city")
+		id.Set(user, 1)
+
+		t.Assert().Equal(&fieldUser{
+			FieldBase: &FieldBase{ID: 1},
+			Name:      "name",
+			Address:   fieldAddress{City: "city"},
+			Billing:   &fieldAddress{City: "billing_city"},
+		}, user)
+		t.Assert().Equal("name", name.Get(user))
+		t.Assert().Equal("city", city.Get(user))
+		t.Assert().Equal("billing_city", billing.Get(user))
+		t.Assert().Equal(1, id.Get(user))
+		t.Assert().Equal("Billing.City", billing.Path())
+
+		*city.Ptr(user) = "new_city"
+		t.Assert().Equal("new_city", user.Address.City)
+	})
+
+	runner.Run(t, "ERR. MustField panics", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Field")
+		t.Description("Check `MustField` panics on error")
+
+		defer func() {
+			t.Assert().NotNil(recover(), "Check panic")
+		}()
+
+		MustField[fieldUser, int]("Unknown")
+	})
+}
+
+func BenchmarkGetAs(b *testing.B) {
+	record := &benchRecord{Amount: 1.5}
+	amount := MustField[benchRecord, float64]("Amount")
+
+	b.Run("GetAttr", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := GetAttr(*record, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetAs", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := GetAs[float64](record, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Field", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if amount.Get(record) != 1.5 {
+				b.Fatal("wrong value")
+			}
+		}
+	})
+}
+
+func BenchmarkFieldSet(b *testing.B) {
+	record := &benchRecord{}
+	amount := MustField[benchRecord, float64]("Amount")
+
+	b.Run("SetAttr", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := SetAttr(record, 1.5, "Amount"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Field", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			amount.Set(record, 1.5)
+		}
+	})
+}
+
+func ExampleField() {
+	type Order struct {
+		ID     int
+		Amount float64
+	}
+
+	amount := MustField[Order, float64]("Amount")
+
+	orders := []Order{{ID: 1, Amount: 1.5}, {ID: 2, Amount: 2.5}}
+
+	var total float64
+	for i := range orders {
+		total += amount.Get(&orders[i])
+		amount.Set(&orders[i], 0)
+	}
+
+	id, err := GetAs[int](orders[1], "ID")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(total, orders, id)
+	// Output: 4 [{1 0} {2 0}] 2
+}
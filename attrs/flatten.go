@@ -0,0 +1,364 @@
+package attrs
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IndexNotation - notation of slice and array indexes in Flatten keys.
+type IndexNotation string
+
+// index notations.
+const (
+	IndexBrackets  IndexNotation = "brackets"  // `items[0].price`
+	IndexSeparator IndexNotation = "separator" // `items.0.price`
+)
+
+// defaultSeparator - Flatten keys separator.
+const defaultSeparator = "."
+
+// maxFlatIndex - max slice index of Unflatten keys, protects from huge allocations.
+const maxFlatIndex = 1 << 16
+
+// WithSeparator - Flatten and Unflatten join names in keys by sep instead of '.'.
+func WithSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// WithIndexNotation - Flatten and Unflatten write slice indexes in keys by notation, IndexBrackets by default.
+func WithIndexNotation(notation IndexNotation) Option {
+	return func(o *options) {
+		o.notation = notation
+	}
+}
+
+// Flatten - struct to flat map of text values by keys like `db.primary.host`, `items[0].price`, `labels.env`.
+// Nested structs, ptrs, interfaces, slices, arrays and maps are flattened, nil ptrs, empty slices and maps
+// have no keys. Values are formatted like by CSVWriter: floats with precision of `round:"2"` tag or
+// WithFloatPrecision by rmath.RoundPy, encoding.TextMarshaler by its text. Unexported fields are skipped.
+// 'obj': struct or ptr struct.
+// 'opts': optional, WithSeparator, WithIndexNotation, WithFloatPrecision, WithTagKey for tag names in keys.
+func Flatten(obj interface{}, opts ...Option) (map[string]string, error) {
+	objValue := reflect.ValueOf(obj)
+	// ptr struct check
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		objValue = objValue.Elem()
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return nil, objError(opFlatten, ErrNotStruct, objValue)
+	}
+
+	o := newOptions(opts)
+
+	spec, err := textFloatSpec(o)
+	if err != nil {
+		return nil, opError(opFlatten, err)
+	}
+
+	f := &flattener{o: o, sep: separator(o), out: make(map[string]string)}
+	// root ptr is visited too, protects from cycles back to it
+	if err := f.flatten(reflect.ValueOf(obj), "", spec); err != nil {
+		return nil, opError(opFlatten, err)
+	}
+
+	return f.out, nil
+}
+
+// Unflatten - set struct fields from flat map of text values by keys like Flatten ones.
+// Nil ptrs, slices and maps are allocated, slices grow to key index. Map of values takes the rest of key
+// as map key, so `labels.app.kubernetes.io` is key `app.kubernetes.io`. Names must not contain separator.
+// Values are parsed like by CSVReader: numbers by conv.StringToFloat64, other values like WithConvert.
+// 'kv': flat map.
+// 'obj': ptr struct.
+// 'opts': optional, WithSeparator, WithIndexNotation, WithTagKey, WithIgnoreMissing to skip unknown keys.
+func Unflatten(kv map[string]string, obj interface{}, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opUnflatten, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opUnflatten, ErrNotStruct, objValue)
+	}
+
+	var (
+		o    = newOptions(opts)
+		u    = &unflattener{o: o, sep: separator(o)}
+		keys = make([]string, 0, len(kv))
+	)
+
+	for key := range kv {
+		keys = append(keys, key)
+	}
+	// keys order for the same error
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		parts, err := u.split(key)
+		if err == nil {
+			err = u.set(objValue.Elem(), parts, kv[key])
+		}
+
+		if err != nil && !(o.merge.ignoreMissing && errors.Is(err, ErrFieldNotInStruct)) {
+			return fieldError(opUnflatten, key, err)
+		}
+	}
+
+	return nil
+}
+
+// separator - keys separator of options.
+func separator(o *options) string {
+	if o.separator == "" {
+		return defaultSeparator
+	}
+
+	return o.separator
+}
+
+// flattener - puts values found while walking a struct to flat map.
+type flattener struct {
+	o       *options
+	sep     string
+	out     map[string]string
+	visited map[ptrKey]bool // pointers on the current path, protects from cycles
+}
+
+// flatten - put value or its nested values to map by key.
+func (f *flattener) flatten(v reflect.Value, key string, spec roundSpec) error {
+	switch {
+	case v.Kind() == reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return f.flatten(v.Elem(), key, spec)
+	case v.Kind() == reflect.Ptr:
+		// nil ptr and cycle check
+		ptr := ptrKey{addr: v.Pointer(), typ: v.Type()}
+		if v.IsNil() || f.visited[ptr] {
+			return nil
+		}
+
+		if f.visited == nil {
+			f.visited = make(map[ptrKey]bool)
+		}
+
+		f.visited[ptr] = true
+		defer delete(f.visited, ptr)
+
+		return f.flatten(v.Elem(), key, spec)
+	case v.Type().Implements(textMarshalerType):
+	case isFlatStruct(v.Type()):
+		return f.flattenStruct(v, key, spec)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := f.flatten(v.Index(i), f.index(key, i), spec); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case v.Kind() == reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := formatText(iter.Key(), spec)
+			if err != nil {
+				return &AttrError{Path: key, Err: err}
+			}
+
+			if err := f.flatten(iter.Value(), joinKey(key, k, f.sep), spec); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	text, err := formatText(v, spec)
+	if err != nil {
+		return fieldError("", key, err)
+	}
+
+	f.out[key] = text
+
+	return nil
+}
+
+// flattenStruct - put exported fields of struct value to map.
+// Field tag sets float formatting of the field and its nested fields.
+func (f *flattener) flattenStruct(v reflect.Value, prefix string, spec roundSpec) error {
+	fields := structFields(v.Type(), f.o)
+	for i := range fields {
+		field := &fields[i]
+		if !field.exported {
+			continue
+		}
+
+		key := joinKey(prefix, field.name, f.sep)
+
+		fieldSpec, err := fieldFloatSpec(field.tag, spec)
+		if err != nil {
+			return &AttrError{Path: key, Err: err}
+		}
+		// is embedded ptr nil check
+		fieldValue, err := fieldByInfo(v, field, false)
+		if err != nil {
+			continue
+		}
+
+		if err := f.flatten(fieldValue, key, fieldSpec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// index - key of slice element.
+func (f *flattener) index(key string, i int) string {
+	if f.o.notation == IndexSeparator {
+		return joinKey(key, strconv.Itoa(i), f.sep)
+	}
+
+	return indexPath(key, i)
+}
+
+// joinKey - key of prefix and name joined by sep.
+func joinKey(prefix, name, sep string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + sep + name
+}
+
+// unflattener - sets values of flat map keys to struct fields.
+type unflattener struct {
+	o   *options
+	sep string
+}
+
+// split - key to parts of names, indexes and map keys.
+func (u *unflattener) split(key string) ([]string, error) {
+	var parts []string
+
+	for _, part := range strings.Split(key, u.sep) {
+		if u.o.notation == IndexSeparator {
+			parts = append(parts, part)
+			continue
+		}
+		// indexes in brackets: `items[0][1]`
+		name, indexes, _ := strings.Cut(part, "[")
+		parts = append(parts, name)
+
+		for indexes != "" {
+			index, rest, ok := strings.Cut(indexes, "]")
+			if !ok || index == "" || rest != "" && rest[0] != '[' {
+				return nil, ErrInvalidPath
+			}
+
+			parts = append(parts, index)
+			indexes = strings.TrimPrefix(rest, "[")
+		}
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, ErrInvalidPath
+		}
+	}
+
+	return parts, nil
+}
+
+// set - set addressable value or its nested value by key parts from text.
+func (u *unflattener) set(v reflect.Value, parts []string, text string) error {
+	if len(parts) == 0 {
+		return setText(v, text)
+	}
+	// dereference pointers, allocate nil ones
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case isFlatStruct(v.Type()):
+		field, err := structField(v, parts[0], true, u.o)
+		if err != nil {
+			return err
+		}
+
+		return u.set(field, parts[1:], text)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		i, err := strconv.Atoi(parts[0])
+		if err != nil || i < 0 || i >= maxFlatIndex || v.Kind() == reflect.Array && i >= v.Len() {
+			return ErrIndexOutOfRange
+		}
+		// grow slice to index
+		if i >= v.Len() {
+			if i >= v.Cap() {
+				grown := reflect.MakeSlice(v.Type(), i+1, i+1)
+				reflect.Copy(grown, v)
+				v.Set(grown)
+			} else {
+				v.SetLen(i + 1)
+			}
+		}
+
+		return u.set(v.Index(i), parts[1:], text)
+	case v.Kind() == reflect.Map:
+		rest := parts[1:]
+		// map of values: the rest of key is map key
+		if !hasFlatElems(v.Type().Elem()) {
+			parts, rest = []string{strings.Join(parts, u.sep)}, nil
+		}
+
+		key, err := parseText(parts[0], v.Type().Key())
+		if err != nil {
+			return err
+		}
+		// map elements are not addressable: change a copy and put it back
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key); cur.IsValid() {
+			elem.Set(cur)
+		}
+
+		if err := u.set(elem, rest, text); err != nil {
+			return err
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(key, elem)
+
+		return nil
+	default:
+		return ErrNotIndexable
+	}
+}
+
+// hasFlatElems - values of type t are flattened to several keys: structs, slices, arrays, maps or ptrs to them.
+func hasFlatElems(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return !t.Implements(textMarshalerType)
+	default:
+		return isFlatStruct(t)
+	}
+}
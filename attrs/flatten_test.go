@@ -0,0 +1,345 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type flatHost struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type flatItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price" round:"2"`
+}
+
+type flatConfig struct {
+	Name     string                 `json:"name"`
+	Primary  flatHost               `json:"primary"`
+	Replica  *flatHost              `json:"replica"`
+	Items    []flatItem             `json:"items"`
+	Ptrs     []*flatItem            `json:"ptrs"`
+	Tags     []string               `json:"tags"`
+	Labels   map[string]string      `json:"labels"`
+	Shards   map[int]flatHost       `json:"shards"`
+	Weights  [2]float64             `json:"weights"`
+	Rate     float64                `json:"rate"`
+	Timeout  time.Duration          `json:"timeout"`
+	Started  time.Time              `json:"started"`
+	Any      interface{}            `json:"any"`
+	Extra    map[string]interface{} `json:"-"`
+	internal string
+}
+
+func TestFlatten(t *testing.T) {
+	testCases := []struct {
+		obj         interface{}
+		opts        []Option
+		expected    map[string]string
+		expectedErr string
+		testName    string
+	}{
+		{
+			obj: flatConfig{
+				Name:     "db",
+				Primary:  flatHost{Host: "primary", Port: 5432},
+				Items:    []flatItem{{SKU: "a", Price: 1.005}, {SKU: "b", Price: 2.675}},
+				Ptrs:     []*flatItem{nil, {SKU: "c"}},
+				Tags:     []string{"x", "y"},
+				Labels:   map[string]string{"env": "prod", "app.kubernetes.io/name": "api"},
+				Shards:   map[int]flatHost{1: {Host: "shard1"}},
+				Weights:  [2]float64{0.25, 0.75},
+				Rate:     0.1,
+				Timeout:  time.Second,
+				Started:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Any:      flatHost{Host: "any"},
+				internal: "internal",
+			},
+			expected: map[string]string{
+				"Name": "db", "Primary.Host": "primary", "Primary.Port": "5432",
+				"Items[0].SKU": "a", "Items[0].Price": "1.00", "Items[1].SKU": "b", "Items[1].Price": "2.67",
+				"Ptrs[1].SKU": "c", "Ptrs[1].Price": "0.00", "Tags[0]": "x", "Tags[1]": "y",
+				"Labels.env": "prod", "Labels.app.kubernetes.io/name": "api", "Shards.1.Host": "shard1", "Shards.1.Port": "0",
+				"Weights[0]": "0.25", "Weights[1]": "0.75", "Rate": "0.1", "Timeout": "1s",
+				"Started": "2024-01-02T03:04:05Z", "Any.Host": "any", "Any.Port": "0",
+			},
+			testName: "OK. Nested values",
+		},
+		{
+			obj: &flatConfig{Primary: flatHost{Host: "primary"}, Items: []flatItem{{SKU: "a"}}, Rate: 1.0 / 3},
+			opts: []Option{
+				WithTagKey("json"), WithSeparator("_"), WithIndexNotation(IndexSeparator), WithFloatPrecision(3),
+			},
+			expected: map[string]string{
+				"name": "", "primary_host": "primary", "primary_port": "0", "items_0_sku": "a", "items_0_price": "0.00",
+				"weights_0": "0.000", "weights_1": "0.000", "rate": "0.333", "timeout": "0s",
+				"started": "0001-01-01T00:00:00Z",
+			},
+			testName: "OK. Tag names, separator, index notation, precision",
+		},
+		{
+			obj:         flatHost{},
+			opts:        []Option{WithFloatPrecision(14)},
+			expectedErr: `Flatten: invalid option: round precision 14 of mode "py" not in 0..13`,
+			testName:    "ERR. Float precision out of range",
+		},
+		{
+			obj:         1,
+			expectedErr: "Flatten: not a struct (got int)",
+			testName:    "ERR. Not a struct",
+		},
+		{
+			obj:         struct{ Ch chan int }{Ch: make(chan int)},
+			expectedErr: "Flatten Ch: wrong field value type (got chan int)",
+			testName:    "ERR. Not formatted field",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Flatten")
+			t.Description("Check func `Flatten`")
+			t.WithParameters(allure.NewParameter("obj", testCase.obj))
+
+			kv, err := Flatten(testCase.obj, testCase.opts...)
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, kv, "Check Flatten")
+		})
+	}
+
+	runner.Run(t, "OK. Cycle", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Flatten")
+		t.Description("Check func `Flatten` with ptr cycle")
+
+		type node struct {
+			Value int
+			Next  *node
+		}
+
+		n := &node{Value: 1}
+		n.Next = &node{Value: 2, Next: n}
+
+		kv, err := Flatten(n)
+		t.Require().NoError(err)
+		t.Assert().Equal(map[string]string{"Value": "1", "Next.Value": "2"}, kv)
+	})
+}
+
+func TestUnflatten(t *testing.T) {
+	testCases := []struct {
+		kv          map[string]string
+		opts        []Option
+		expected    flatConfig
+		expectedErr string
+		testName    string
+	}{
+		{
+			kv: map[string]string{
+				"Name": "db", "Replica.Host": "replica", "Items[1].Price": "2.5", "Ptrs[1].SKU": "c",
+				"Tags": "x, y", "Labels.app.kubernetes.io/name": "api", "Shards.1.Port": "1e3",
+				"Weights[1]": "0.75", "Timeout": "1s", "Started": "2024-01-02",
+			},
+			expected: flatConfig{
+				Name:    "db",
+				Replica: &flatHost{Host: "replica"},
+				Items:   []flatItem{{}, {Price: 2.5}},
+				Ptrs:    []*flatItem{nil, {SKU: "c"}},
+				Tags:    []string{"x", "y"},
+				Labels:  map[string]string{"app.kubernetes.io/name": "api"},
+				Shards:  map[int]flatHost{1: {Port: 1000}},
+				Weights: [2]float64{0, 0.75},
+				Timeout: time.Second,
+				Started: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			testName: "OK. Nested values, slices grow",
+		},
+		{
+			kv: map[string]string{"primary_host": "primary", "items_0_sku": "a", "unknown_key": "x", "any": "1"},
+			opts: []Option{
+				WithTagKey("json"), WithSeparator("_"), WithIndexNotation(IndexSeparator), WithIgnoreMissing(),
+			},
+			expected: flatConfig{Primary: flatHost{Host: "primary"}, Items: []flatItem{{SKU: "a"}}, Any: "1"},
+			testName: "OK. Tag names, separator, index notation, unknown keys",
+		},
+		{
+			kv: map[string]string{"Primary.Port": "x"},
+			expectedErr: "Unflatten Primary.Port: value conversion failed: " +
+				`unparsed tail left after parsing float64 from "x": "x" (expected int, got string)`,
+			testName: "ERR. Conversion",
+		},
+		{
+			kv:          map[string]string{"Primary.Unknown": "x"},
+			expectedErr: "Unflatten Primary.Unknown: field not in struct",
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			kv:          map[string]string{"Items[70000].SKU": "a"},
+			expectedErr: "Unflatten Items[70000].SKU: index out of range",
+			testName:    "ERR. Index limit",
+		},
+		{
+			kv:          map[string]string{"Weights[2]": "1"},
+			expectedErr: "Unflatten Weights[2]: index out of range",
+			testName:    "ERR. Array index",
+		},
+		{
+			kv:          map[string]string{"Items[0.SKU": "a"},
+			expectedErr: "Unflatten Items[0.SKU: invalid field path",
+			testName:    "ERR. Invalid key",
+		},
+		{
+			kv:          map[string]string{"Name.First": "a"},
+			expectedErr: "Unflatten Name.First: field not indexable",
+			testName:    "ERR. Not indexable",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Unflatten")
+			t.Description("Check func `Unflatten`")
+			t.WithParameters(allure.NewParameter("kv", testCase.kv))
+
+			var actual flatConfig
+
+			err := Unflatten(testCase.kv, &actual, testCase.opts...)
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, actual, "Check Unflatten")
+		})
+	}
+
+	runner.Run(t, "OK. Round trip", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Unflatten")
+		t.Description("Check func `Unflatten` of `Flatten` result")
+
+		var (
+			config = flatConfig{
+				Name:     "db",
+				Primary:  flatHost{Host: "primary", Port: 5432},
+				Items:    []flatItem{{SKU: "a", Price: 1.005}, {SKU: "b", Price: 2.675}},
+				Ptrs:     []*flatItem{nil, {SKU: "c"}},
+				Tags:     []string{"x", "y"},
+				Labels:   map[string]string{"env": "prod", "app.kubernetes.io/name": "api"},
+				Shards:   map[int]flatHost{1: {Host: "shard1"}},
+				Weights:  [2]float64{0.25, 0.75},
+				Rate:     0.1,
+				Timeout:  time.Second,
+				Started:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Any:      flatHost{Host: "any"},
+				internal: "internal",
+			}
+			// prices rounded by tags, interface and unexported fields are not set
+			expected = flatConfig{
+				Name:    "db",
+				Primary: flatHost{Host: "primary", Port: 5432},
+				Items:   []flatItem{{SKU: "a", Price: 1}, {SKU: "b", Price: 2.67}},
+				Ptrs:    []*flatItem{nil, {SKU: "c"}},
+				Tags:    []string{"x", "y"},
+				Labels:  map[string]string{"env": "prod", "app.kubernetes.io/name": "api"},
+				Shards:  map[int]flatHost{1: {Host: "shard1"}},
+				Weights: [2]float64{0.25, 0.75},
+				Rate:    0.1,
+				Timeout: time.Second,
+				Started: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+		)
+
+		for _, opts := range [][]Option{nil, {WithIndexNotation(IndexSeparator), WithSeparator("/")}} {
+			kv, err := Flatten(config, opts...)
+			t.Require().NoError(err)
+			delete(kv, "Any.Host")
+			delete(kv, "Any.Port")
+			delete(kv, "Any/Host")
+			delete(kv, "Any/Port")
+
+			var actual flatConfig
+			t.Require().NoError(Unflatten(kv, &actual, opts...))
+			t.Assert().Equal(expected, actual)
+		}
+	})
+
+	runner.Run(t, "ERR. Not a ptr struct", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Unflatten")
+		t.Description("Check func `Unflatten` with wrong obj")
+
+		t.Assert().ErrorIs(Unflatten(nil, flatConfig{}), ErrNotPointerStruct)
+		t.Assert().ErrorIs(Unflatten(nil, new(int)), ErrNotStruct)
+	})
+}
+
+func ExampleFlatten() {
+	type Host struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	type Config struct {
+		Primary  Host    `json:"primary"`
+		Replicas []Host  `json:"replicas"`
+		Ratio    float64 `json:"ratio" round:"2"`
+	}
+
+	kv, err := Flatten(Config{Primary: Host{Host: "db1", Port: 5432}, Replicas: []Host{{Host: "db2"}}, Ratio: 1.0 / 3},
+		WithTagKey("json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	keys := make([]string, 0, len(kv))
+	for key := range kv {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, kv[key])
+	}
+
+	var cfg Config
+	if err := Unflatten(map[string]string{"primary.host": "db3", "replicas[1].port": "6432"}, &cfg,
+		WithTagKey("json")); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(cfg)
+	// Output:
+	// primary.host=db1
+	// primary.port=5432
+	// ratio=0.33
+	// replicas[0].host=db2
+	// replicas[0].port=0
+	// {{db3 0} [{ 0} { 6432}] 0}
+}
@@ -0,0 +1,93 @@
+package attrs
+
+import "reflect"
+
+// AttrGetter - struct with generated GetAttr by field name, see cmd/attrsgen.
+// GetAttr uses it instead of reflection for field names without options.
+type AttrGetter interface {
+	GetAttr(name string) (interface{}, error)
+}
+
+// AttrSetter - ptr struct with generated SetAttr by field name, see cmd/attrsgen.
+// SetAttr uses it instead of reflection for field names without options.
+type AttrSetter interface {
+	SetAttr(name string, value interface{}) error
+}
+
+// FloatRounder - ptr struct with generated RoundFloatFields, see cmd/attrsgen.
+// RoundStructFloatFields uses it instead of reflection without options.
+type FloatRounder interface {
+	RoundFloatFields(precision int) error
+}
+
+// Mapper - struct with generated ToMap, see cmd/attrsgen. ToMap uses it instead of reflection without options.
+type Mapper interface {
+	ToMap() (map[string]interface{}, error)
+}
+
+// generated interfaces.
+var (
+	attrGetterType   = reflect.TypeOf((*AttrGetter)(nil)).Elem()
+	attrSetterType   = reflect.TypeOf((*AttrSetter)(nil)).Elem()
+	floatRounderType = reflect.TypeOf((*FloatRounder)(nil)).Elem()
+	mapperType       = reflect.TypeOf((*Mapper)(nil)).Elem()
+)
+
+// generatedSet - generated interfaces implemented by own methods of type.
+type generatedSet uint8
+
+// generated interfaces of generatedSet, in generatedIfaces order.
+const (
+	genGetter generatedSet = 1 << iota
+	genSetter
+	genRounder
+	genMapper
+)
+
+// generatedIfaces - generated interfaces by generatedSet bit.
+var generatedIfaces = []reflect.Type{attrGetterType, attrSetterType, floatRounderType, mapperType}
+
+// isGenerated - obj of struct or ptr struct type t implements generated interface by its own methods.
+// Methods of embedded fields are promoted to struct, so for structs embedding types with the same methods
+// it is not known whose methods these are, and reflection is used.
+func isGenerated(t reflect.Type, iface generatedSet) bool {
+	if t.Kind() == reflect.Ptr {
+		return getTypeInfo(t.Elem()).ptrGenerated&iface != 0
+	}
+
+	return getTypeInfo(t).generated&iface != 0
+}
+
+// generatedMethods - generated interfaces implemented by own methods of type t, computed once by buildTypeInfo.
+func generatedMethods(t reflect.Type) generatedSet {
+	var set generatedSet
+
+	for i, iface := range generatedIfaces {
+		if ownMethods(t, iface) {
+			set |= 1 << i
+		}
+	}
+
+	return set
+}
+
+// ownMethods - type t implements iface and no embedded field of its struct does.
+func ownMethods(t, iface reflect.Type) bool {
+	if t == nil || !t.Implements(iface) {
+		return false
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		// embedded field methods check
+		if sf.Anonymous && (sf.Type.Implements(iface) || reflect.PointerTo(sf.Type).Implements(iface)) {
+			return false
+		}
+	}
+
+	return true
+}
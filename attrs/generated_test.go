@@ -0,0 +1,159 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+// GenStruct - struct with methods like generated ones, returns values different from reflection.
+type GenStruct struct {
+	Name  string
+	Value float64
+}
+
+func (s GenStruct) GetAttr(string) (interface{}, error) {
+	return "generated", nil
+}
+
+func (s *GenStruct) SetAttr(_ string, _ interface{}) error {
+	s.Name = "generated"
+	return nil
+}
+
+func (s *GenStruct) RoundFloatFields(int) error {
+	s.Value = -1
+	return nil
+}
+
+func (s GenStruct) ToMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"generated": true}, nil
+}
+
+// genEmbedded - struct with promoted methods of embedded struct.
+type genEmbedded struct {
+	GenStruct
+	Title string
+}
+
+// genOwn - struct with own methods and embedded struct with methods.
+type genOwn struct {
+	*GenStruct
+}
+
+func (s genOwn) GetAttr(string) (interface{}, error) {
+	return "own", nil
+}
+
+// genMapError - struct with generated ToMap returning error.
+type genMapError struct {
+	Name string
+}
+
+func (s genMapError) ToMap() (map[string]interface{}, error) {
+	return nil, ErrConversion
+}
+
+func TestGenerated(t *testing.T) {
+	testCases := []struct {
+		obj      interface{}
+		ptr      interface{}
+		opts     []Option
+		name     string
+		expected []interface{}
+		testName string
+	}{
+		{
+			obj:  GenStruct{Name: "name", Value: 1.5},
+			ptr:  &GenStruct{Name: "name", Value: 1.5},
+			name: "Name",
+			expected: []interface{}{
+				"generated", &GenStruct{Name: "generated", Value: -1}, map[string]interface{}{"generated": true},
+			},
+			testName: "OK. Generated methods",
+		},
+		{
+			obj:  GenStruct{Name: "name", Value: 1.5},
+			ptr:  &GenStruct{Name: "name", Value: 1.55},
+			opts: []Option{WithConvert()},
+			name: "Name",
+			expected: []interface{}{
+				"name", &GenStruct{Name: "new", Value: 1.6}, map[string]interface{}{"Name": "name", "Value": 1.5},
+			},
+			testName: "OK. Reflection with options",
+		},
+		{
+			obj:  genEmbedded{GenStruct: GenStruct{Name: "name"}},
+			ptr:  &genEmbedded{GenStruct: GenStruct{Name: "name", Value: 1.55}},
+			name: "Title",
+			expected: []interface{}{
+				"",
+				&genEmbedded{GenStruct: GenStruct{Name: "name", Value: 1.6}, Title: "new"},
+				map[string]interface{}{"GenStruct": map[string]interface{}{"Name": "name", "Value": 0.0}, "Title": ""},
+			},
+			testName: "OK. Reflection for promoted methods",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Generated")
+			t.Description("Check generated methods are used instead of reflection")
+			t.WithParameters(allure.NewParameter("obj", testCase.obj))
+
+			value, err := GetAttr(testCase.obj, testCase.name, testCase.opts...)
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected[0], value, "Check GetAttr")
+
+			t.Require().NoError(SetAttr(testCase.ptr, "new", testCase.name, testCase.opts...))
+			t.Require().NoError(RoundStructFloatFields(testCase.ptr, 1, testCase.opts...))
+			t.Assert().Equal(testCase.expected[1], testCase.ptr, "Check SetAttr and RoundStructFloatFields")
+
+			m, err := ToMap(testCase.obj, testCase.opts...)
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected[2], m, "Check ToMap")
+		})
+	}
+
+	runner.Run(t, "ERR. Generated ToMap error", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Generated")
+		t.Description("Check func `ToMap` returns error of generated method")
+
+		_, err := ToMap(genMapError{})
+		t.Assert().ErrorIs(err, ErrConversion)
+		t.Assert().EqualError(err, "ToMap: value conversion failed")
+	})
+
+	runner.Run(t, "OK. Paths by reflection", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Generated")
+		t.Description("Check paths are resolved by reflection")
+
+		value, err := GetAttr(genOwn{GenStruct: &GenStruct{Name: "name"}}, "GenStruct.Name")
+		t.Require().NoError(err)
+		t.Assert().Equal("name", value)
+	})
+
+	runner.Run(t, "OK. Own and embedded methods", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Generated")
+		t.Description("Check func `isGenerated`")
+
+		t.Assert().True(isGenerated(reflect.TypeOf(GenStruct{}), genGetter))
+		t.Assert().True(isGenerated(reflect.TypeOf(&GenStruct{}), genSetter))
+		t.Assert().True(isGenerated(reflect.TypeOf(&GenStruct{}), genGetter))
+		t.Assert().False(isGenerated(reflect.TypeOf(GenStruct{}), genSetter))
+		t.Assert().False(isGenerated(reflect.TypeOf(genEmbedded{}), genGetter))
+		t.Assert().False(isGenerated(reflect.TypeOf(genOwn{}), genGetter))
+	})
+}
@@ -0,0 +1,564 @@
+// Code generated by attrsgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/ruauka/tools-go/attrs"
+	"github.com/ruauka/tools-go/rmath"
+)
+
+// GetAttr - get field value by name without reflection, used by attrs.GetAttr.
+func (c Customer) GetAttr(name string) (interface{}, error) {
+	switch name {
+	case "Name":
+		return c.Name, nil
+	case "Age":
+		return c.Age, nil
+	case "Balance":
+		return c.Balance, nil
+	case "Items":
+		return c.Items, nil
+	default:
+		return nil, attrsgenError("GetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+}
+
+// SetAttr - set field value by name without reflection, used by attrs.SetAttr.
+func (c *Customer) SetAttr(name string, value interface{}) error {
+	switch name {
+	case "Name":
+		v, ok := value.(string)
+		if !ok {
+			return attrsgenTypeError[string](name, value)
+		}
+
+		c.Name = v
+	case "Age":
+		v, ok := value.(int)
+		if !ok {
+			return attrsgenTypeError[int](name, value)
+		}
+
+		c.Age = v
+	case "Balance":
+		v, ok := value.(float64)
+		if !ok {
+			return attrsgenTypeError[float64](name, value)
+		}
+
+		c.Balance = v
+	case "Items":
+		v, ok := value.([]Item)
+		if !ok {
+			return attrsgenTypeError[[]Item](name, value)
+		}
+
+		c.Items = v
+	default:
+		return attrsgenError("SetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+
+	return nil
+}
+
+// RoundFloatFields - round float fields without reflection, used by attrs.RoundStructFloatFields.
+func (c *Customer) RoundFloatFields(precision int) error {
+	c.Balance = rmath.Round(c.Balance, 2)
+	for i := range c.Items {
+		if err := attrs.RoundStructFloatFields(&c.Items[i], precision); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ToMap - exported fields to map without reflection, used by attrs.ToMap.
+func (c Customer) ToMap() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, 4)
+
+	m["Name"] = c.Name
+	m["Age"] = c.Age
+	m["Balance"] = c.Balance
+	m["Items"] = c.Items
+
+	return m, nil
+}
+
+// GetAttr - get field value by name without reflection, used by attrs.GetAttr.
+func (o Order) GetAttr(name string) (interface{}, error) {
+	switch name {
+	case "Base":
+		return o.Base, nil
+	case "ID":
+		return o.Base.ID, nil
+	case "Version":
+		return o.Base.Version, nil
+	case "Audit":
+		if o.Audit == nil {
+			return nil, nil
+		}
+
+		return *o.Audit, nil
+	case "CreatedBy":
+		if o.Audit == nil {
+			return nil, attrsgenError("GetAttr", name, attrs.ErrNilPointer)
+		}
+
+		return o.Audit.CreatedBy, nil
+	case "UpdatedAt":
+		if o.Audit == nil {
+			return nil, attrsgenError("GetAttr", name, attrs.ErrNilPointer)
+		}
+
+		if o.Audit.UpdatedAt == nil {
+			return nil, nil
+		}
+
+		return *o.Audit.UpdatedAt, nil
+	case "Customer":
+		if o.Customer == nil {
+			return nil, nil
+		}
+
+		return *o.Customer, nil
+	case "Items":
+		return o.Items, nil
+	case "Prices":
+		return o.Prices, nil
+	case "Rate":
+		if o.Rate == nil {
+			return nil, nil
+		}
+
+		return *o.Rate, nil
+	case "Total":
+		return o.Total, nil
+	case "Discount":
+		return o.Discount, nil
+	case "Meta":
+		return o.Meta, nil
+	case "Stock":
+		return o.Stock, nil
+	case "Grid":
+		return o.Grid, nil
+	case "Tags":
+		return o.Tags, nil
+	case "CreatedAt":
+		return o.CreatedAt, nil
+	case "Skip":
+		return o.Skip, nil
+	case "note":
+		return nil, attrsgenError("GetAttr", name, attrs.ErrUnexportedField)
+	default:
+		return nil, attrsgenError("GetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+}
+
+// SetAttr - set field value by name without reflection, used by attrs.SetAttr.
+func (o *Order) SetAttr(name string, value interface{}) error {
+	switch name {
+	case "Base":
+		v, ok := value.(Base)
+		if !ok {
+			return attrsgenTypeError[Base](name, value)
+		}
+
+		o.Base = v
+	case "ID":
+		v, ok := value.(int)
+		if !ok {
+			return attrsgenTypeError[int](name, value)
+		}
+
+		o.Base.ID = v
+	case "Version":
+		v, ok := value.(uint8)
+		if !ok {
+			return attrsgenTypeError[uint8](name, value)
+		}
+
+		o.Base.Version = v
+	case "Audit":
+		switch v := value.(type) {
+		case *Audit:
+			o.Audit = v
+		case Audit:
+			if o.Audit == nil {
+				o.Audit = new(Audit)
+			}
+			*o.Audit = v
+		default:
+			return attrsgenTypeError[*Audit](name, value)
+		}
+	case "CreatedBy":
+		if o.Audit == nil {
+			o.Audit = new(Audit)
+		}
+
+		v, ok := value.(string)
+		if !ok {
+			return attrsgenTypeError[string](name, value)
+		}
+
+		o.Audit.CreatedBy = v
+	case "UpdatedAt":
+		if o.Audit == nil {
+			o.Audit = new(Audit)
+		}
+
+		switch v := value.(type) {
+		case *time.Time:
+			o.Audit.UpdatedAt = v
+		case time.Time:
+			if o.Audit.UpdatedAt == nil {
+				o.Audit.UpdatedAt = new(time.Time)
+			}
+			*o.Audit.UpdatedAt = v
+		default:
+			return attrsgenTypeError[*time.Time](name, value)
+		}
+	case "Customer":
+		switch v := value.(type) {
+		case *Customer:
+			o.Customer = v
+		case Customer:
+			if o.Customer == nil {
+				o.Customer = new(Customer)
+			}
+			*o.Customer = v
+		default:
+			return attrsgenTypeError[*Customer](name, value)
+		}
+	case "Items":
+		v, ok := value.([]Item)
+		if !ok {
+			return attrsgenTypeError[[]Item](name, value)
+		}
+
+		o.Items = v
+	case "Prices":
+		v, ok := value.([]float64)
+		if !ok {
+			return attrsgenTypeError[[]float64](name, value)
+		}
+
+		o.Prices = v
+	case "Rate":
+		switch v := value.(type) {
+		case *float64:
+			o.Rate = v
+		case float64:
+			if o.Rate == nil {
+				o.Rate = new(float64)
+			}
+			*o.Rate = v
+		default:
+			return attrsgenTypeError[*float64](name, value)
+		}
+	case "Total":
+		v, ok := value.(float32)
+		if !ok {
+			return attrsgenTypeError[float32](name, value)
+		}
+
+		o.Total = v
+	case "Discount":
+		v, ok := value.(Percent)
+		if !ok {
+			return attrsgenTypeError[Percent](name, value)
+		}
+
+		o.Discount = v
+	case "Meta":
+		v, ok := value.(map[string]float64)
+		if !ok {
+			return attrsgenTypeError[map[string]float64](name, value)
+		}
+
+		o.Meta = v
+	case "Stock":
+		v, ok := value.(map[string]Item)
+		if !ok {
+			return attrsgenTypeError[map[string]Item](name, value)
+		}
+
+		o.Stock = v
+	case "Grid":
+		v, ok := value.([2][]float64)
+		if !ok {
+			return attrsgenTypeError[[2][]float64](name, value)
+		}
+
+		o.Grid = v
+	case "Tags":
+		v, ok := value.([]string)
+		if !ok {
+			return attrsgenTypeError[[]string](name, value)
+		}
+
+		o.Tags = v
+	case "CreatedAt":
+		v, ok := value.(time.Time)
+		if !ok {
+			return attrsgenTypeError[time.Time](name, value)
+		}
+
+		o.CreatedAt = v
+	case "Skip":
+		v, ok := value.(float64)
+		if !ok {
+			return attrsgenTypeError[float64](name, value)
+		}
+
+		o.Skip = v
+	case "note":
+		return attrsgenError("SetAttr", name, attrs.ErrUnexportedField)
+	default:
+		return attrsgenError("SetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+
+	return nil
+}
+
+// RoundFloatFields - round float fields without reflection, used by attrs.RoundStructFloatFields.
+func (o *Order) RoundFloatFields(precision int) error {
+	if o.Customer != nil {
+		if err := attrs.RoundStructFloatFields(o.Customer, precision); err != nil {
+			return err
+		}
+	}
+	for i := range o.Items {
+		if err := attrs.RoundStructFloatFields(&o.Items[i], precision); err != nil {
+			return err
+		}
+	}
+	for i1 := range o.Prices {
+		o.Prices[i1] = rmath.Round(o.Prices[i1], 1)
+	}
+	if o.Rate != nil {
+		*o.Rate = rmath.RoundUp(*o.Rate, precision)
+	}
+	o.Total = float32(rmath.Round(float64(o.Total), precision))
+	o.Discount = Percent(rmath.RoundHalfEven(float64(o.Discount), 1))
+	for k, v := range o.Meta {
+		v = rmath.Round(v, precision)
+		o.Meta[k] = v
+	}
+	for k1, v1 := range o.Stock {
+		if err := attrs.RoundStructFloatFields(&v1, 3, attrs.WithRoundMode(attrs.RoundFloor)); err != nil {
+			return err
+		}
+		o.Stock[k1] = v1
+	}
+	for i2 := range o.Grid {
+		for i3 := range o.Grid[i2] {
+			o.Grid[i2][i3] = rmath.Round(o.Grid[i2][i3], precision)
+		}
+	}
+
+	return nil
+}
+
+// ToMap - exported fields to map without reflection, used by attrs.ToMap.
+func (o Order) ToMap() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, 14)
+
+	var err error
+
+	if m["Base"], err = attrs.ToMap(o.Base); err != nil {
+		return nil, err
+	}
+
+	if o.Audit == nil {
+		m["Audit"] = nil
+	} else if m["Audit"], err = attrs.ToMap(*o.Audit); err != nil {
+		return nil, err
+	}
+
+	if o.Customer == nil {
+		m["Customer"] = nil
+	} else if m["Customer"], err = o.Customer.ToMap(); err != nil {
+		return nil, err
+	}
+
+	m["Items"] = o.Items
+	m["Prices"] = o.Prices
+	if o.Rate == nil {
+		m["Rate"] = nil
+	} else {
+		m["Rate"] = *o.Rate
+	}
+
+	m["Total"] = o.Total
+	m["Discount"] = o.Discount
+	m["Meta"] = o.Meta
+	m["Stock"] = o.Stock
+	m["Grid"] = o.Grid
+	m["Tags"] = o.Tags
+	m["CreatedAt"] = o.CreatedAt
+	m["Skip"] = o.Skip
+
+	return m, nil
+}
+
+// GetAttr - get field value by name without reflection, used by attrs.GetAttr.
+func (e Event) GetAttr(name string) (interface{}, error) {
+	switch name {
+	case "Name":
+		return e.Name, nil
+	case "Payload":
+		return e.Payload, nil
+	case "Values":
+		return e.Values, nil
+	case "Ref":
+		if e.Ref == nil {
+			return nil, nil
+		}
+
+		return *e.Ref, nil
+	case "Err":
+		return e.Err, nil
+	default:
+		return nil, attrsgenError("GetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+}
+
+// SetAttr - set field value by name without reflection, used by attrs.SetAttr.
+func (e *Event) SetAttr(name string, value interface{}) error {
+	switch name {
+	case "Name":
+		v, ok := value.(string)
+		if !ok {
+			return attrsgenTypeError[string](name, value)
+		}
+
+		e.Name = v
+	case "Payload":
+		return attrsgenTypeError[interface{}](name, value)
+	case "Values":
+		v, ok := value.([]float64)
+		if !ok {
+			return attrsgenTypeError[[]float64](name, value)
+		}
+
+		e.Values = v
+	case "Ref":
+		switch v := value.(type) {
+		case *interface{}:
+			e.Ref = v
+		default:
+			return attrsgenTypeError[*interface{}](name, value)
+		}
+	case "Err":
+		return attrsgenTypeError[error](name, value)
+	default:
+		return attrsgenError("SetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+
+	return nil
+}
+
+// ToMap - exported fields to map without reflection, used by attrs.ToMap.
+func (e Event) ToMap() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, 5)
+
+	m["Name"] = e.Name
+	m["Payload"] = e.Payload
+	m["Values"] = e.Values
+	if e.Ref == nil {
+		m["Ref"] = nil
+	} else {
+		m["Ref"] = *e.Ref
+	}
+
+	m["Err"] = e.Err
+
+	return m, nil
+}
+
+// GetAttr - get field value by name without reflection, used by attrs.GetAttr.
+func (n Node) GetAttr(name string) (interface{}, error) {
+	switch name {
+	case "Value":
+		return n.Value, nil
+	case "Next":
+		if n.Next == nil {
+			return nil, nil
+		}
+
+		return *n.Next, nil
+	default:
+		return nil, attrsgenError("GetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+}
+
+// SetAttr - set field value by name without reflection, used by attrs.SetAttr.
+func (n *Node) SetAttr(name string, value interface{}) error {
+	switch name {
+	case "Value":
+		v, ok := value.(float64)
+		if !ok {
+			return attrsgenTypeError[float64](name, value)
+		}
+
+		n.Value = v
+	case "Next":
+		switch v := value.(type) {
+		case *Node:
+			n.Next = v
+		case Node:
+			if n.Next == nil {
+				n.Next = new(Node)
+			}
+			*n.Next = v
+		default:
+			return attrsgenTypeError[*Node](name, value)
+		}
+	default:
+		return attrsgenError("SetAttr", name, attrs.ErrFieldNotInStruct)
+	}
+
+	return nil
+}
+
+// ToMap - exported fields to map without reflection, used by attrs.ToMap.
+func (n Node) ToMap() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, 2)
+
+	var err error
+
+	m["Value"] = n.Value
+	if n.Next == nil {
+		m["Next"] = nil
+	} else if m["Next"], err = n.Next.ToMap(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// attrsgenError - error of generated method like in attrs funcs.
+func attrsgenError(op, name string, err error) error {
+	return &attrs.AttrError{Op: op, Path: name, Err: err}
+}
+
+// attrsgenTypeError - error of generated SetAttr for value of wrong type like in attrs.SetAttr.
+func attrsgenTypeError[T any](name string, value interface{}) error {
+	expected := reflect.TypeOf((*T)(nil)).Elem()
+	// ptr field gets value by its elem
+	if expected.Kind() == reflect.Ptr && value != nil {
+		expected = expected.Elem()
+	}
+
+	return &attrs.AttrError{
+		Op:       "SetAttr",
+		Path:     name,
+		Err:      attrs.ErrWrongFieldValueType,
+		Expected: expected,
+		Actual:   reflect.TypeOf(value),
+	}
+}
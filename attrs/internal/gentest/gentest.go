@@ -0,0 +1,78 @@
+// Package gentest - structs with methods generated by attrsgen for tests of attrs funcs.
+package gentest
+
+import "time"
+
+//go:generate go run github.com/ruauka/tools-go/cmd/attrsgen
+
+// Percent - named float type.
+type Percent float64
+
+// Base - embedded struct without generated methods.
+type Base struct {
+	ID      int
+	Version uint8
+}
+
+// Audit - embedded by ptr struct without generated methods.
+type Audit struct {
+	CreatedBy string
+	UpdatedAt *time.Time
+}
+
+// Item - nested struct without generated methods.
+type Item struct {
+	SKU   string
+	Price float64
+	Qty   int
+}
+
+// Customer - nested struct with generated methods.
+//
+//attrs:generate
+type Customer struct {
+	Name    string
+	Age     int
+	Balance float64 `round:"2"`
+	Items   []Item
+}
+
+// Order - struct with generated methods.
+//
+//attrs:generate
+type Order struct {
+	Base
+	*Audit
+	Customer  *Customer
+	Items     []Item
+	Prices    []float64 `round:"1"`
+	Rate      *float64  `round:",ceil"`
+	Total     float32
+	Discount  Percent `round:"1,half_even"`
+	Meta      map[string]float64
+	Stock     map[string]Item `round:"3,floor"`
+	Grid      [2][]float64
+	Tags      []string
+	CreatedAt time.Time
+	Skip      float64 `round:"-"`
+	note      string
+}
+
+// Event - struct with interface field: RoundFloatFields is not generated.
+//
+//attrs:generate
+type Event struct {
+	Name    string
+	Payload interface{}
+	Values  []float64
+	Ref     *interface{}
+	Err     error
+}
+
+// Node - recursive struct: RoundFloatFields is not generated.
+//
+//attrs:generate
+type Node struct {
+	Value float64
+	Next  *Node
+}
@@ -0,0 +1,291 @@
+package gentest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+
+	"github.com/ruauka/tools-go/attrs"
+)
+
+// reflect* - structs without generated methods, attrs funcs use reflection for them.
+type (
+	reflectOrder Order
+	reflectEvent Event
+	reflectNode  Node
+)
+
+func newOrder() Order {
+	rate := 1.2345
+
+	return Order{
+		Base:      Base{ID: 1, Version: 2},
+		Customer:  &Customer{Name: "john", Age: 30, Balance: 10.555, Items: []Item{{SKU: "c", Price: 1.555}}},
+		Items:     []Item{{SKU: "a", Price: 1.555, Qty: 1}, {SKU: "b", Price: 2.445, Qty: 2}},
+		Prices:    []float64{1.25, 2.35},
+		Rate:      &rate,
+		Total:     3.14159,
+		Discount:  0.25,
+		Meta:      map[string]float64{"a": 1.005, "b": 2.675},
+		Stock:     map[string]Item{"a": {SKU: "a", Price: 3.3339}},
+		Grid:      [2][]float64{{1.111, 2.225}, nil},
+		Tags:      []string{"new"},
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Skip:      9.999,
+		note:      "note",
+	}
+}
+
+func TestGetAttr(t *testing.T) {
+	withAudit := newOrder()
+	withAudit.Audit = &Audit{CreatedBy: "admin"}
+
+	testCases := []struct {
+		obj      Order
+		names    []string
+		testName string
+	}{
+		{
+			obj: newOrder(),
+			names: []string{
+				"Base", "ID", "Version", "Audit", "Customer", "Items", "Prices", "Rate", "Total", "Discount",
+				"Meta", "Stock", "Grid", "Tags", "CreatedAt", "Skip", "Customer.Name", "Items[1].Price",
+			},
+			testName: "OK. Fields",
+		},
+		{
+			obj:      withAudit,
+			names:    []string{"Audit", "CreatedBy", "UpdatedAt"},
+			testName: "OK. Promoted fields",
+		},
+		{
+			obj:      Order{},
+			names:    []string{"Customer", "Rate", "Items"},
+			testName: "OK. Nil fields",
+		},
+		{
+			obj:      newOrder(),
+			names:    []string{"note", "Unknown", "CreatedBy", "UpdatedAt", "", "Customer..Name"},
+			testName: "ERR. Invalid fields",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("attrsgen")
+			t.Description("Check generated `GetAttr` is the same as reflection")
+			t.WithParameters(allure.NewParameter("names", testCase.names))
+
+			for _, name := range testCase.names {
+				expected, expectedErr := attrs.GetAttr(reflectOrder(testCase.obj), name)
+				actual, err := attrs.GetAttr(testCase.obj, name)
+
+				t.Assert().Equal(expected, actual, name)
+				assertSameError(t, expectedErr, err, name)
+			}
+		})
+	}
+}
+
+func TestSetAttr(t *testing.T) {
+	var (
+		rate     = 2.5
+		customer = &Customer{Name: "jane"}
+		ts       = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	)
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		testName string
+	}{
+		{name: "ID", value: 5, testName: "OK. Promoted field"},
+		{name: "Base", value: Base{ID: 7}, testName: "OK. Embedded field"},
+		{name: "CreatedBy", value: "admin", testName: "OK. Promoted field via nil ptr"},
+		{name: "UpdatedAt", value: ts, testName: "OK. Promoted ptr field by elem"},
+		{name: "Rate", value: rate, testName: "OK. Ptr field by elem"},
+		{name: "Rate", value: &rate, testName: "OK. Ptr field by ptr"},
+		{name: "Rate", value: (*float64)(nil), testName: "OK. Ptr field by nil ptr"},
+		{name: "Customer", value: customer, testName: "OK. Struct ptr field"},
+		{name: "Discount", value: Percent(0.5), testName: "OK. Named type"},
+		{name: "Tags", value: []string(nil), testName: "OK. Nil slice"},
+		{name: "Customer.Name", value: "jane", testName: "OK. Path"},
+		{name: "Rate", value: nil, testName: "ERR. Nil value"},
+		{name: "Rate", value: "2.5", testName: "ERR. Wrong ptr elem type"},
+		{name: "Discount", value: 0.5, testName: "ERR. Named type"},
+		{name: "Total", value: 1.5, testName: "ERR. Wrong type"},
+		{name: "note", value: "x", testName: "ERR. Unexported field"},
+		{name: "Unknown", value: 1, testName: "ERR. Field not in struct"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("attrsgen")
+			t.Description("Check generated `SetAttr` is the same as reflection")
+			t.WithParameters(
+				allure.NewParameter("name", testCase.name),
+				allure.NewParameter("value", testCase.value),
+			)
+
+			expected, actual := reflectOrder(newOrder()), newOrder()
+
+			expectedErr := attrs.SetAttr(&expected, testCase.value, testCase.name)
+			err := attrs.SetAttr(&actual, testCase.value, testCase.name)
+
+			assertSameError(t, expectedErr, err, testCase.name)
+			t.Assert().Equal(Order(expected), actual, "Check SetAttr")
+		})
+	}
+
+	runner.Run(t, "ERR. Interface fields", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("attrsgen")
+		t.Description("Check generated `SetAttr` of interface fields is the same as reflection")
+
+		for _, name := range []string{"Payload", "Ref", "Err"} {
+			expected, actual := reflectEvent{}, Event{}
+			assertSameError(t, attrs.SetAttr(&expected, 1, name), attrs.SetAttr(&actual, 1, name), name)
+			assertSameError(t, attrs.SetAttr(&expected, nil, name), attrs.SetAttr(&actual, nil, name), name)
+		}
+	})
+}
+
+func TestRoundStructFloatFields(t *testing.T) {
+	payload := interface{}(Item{Price: 1.555})
+
+	testCases := []struct {
+		expected  interface{}
+		actual    interface{}
+		precision int
+		testName  string
+	}{
+		{
+			expected:  func() *reflectOrder { o := reflectOrder(newOrder()); return &o }(),
+			actual:    func() *Order { o := newOrder(); return &o }(),
+			precision: 2,
+			testName:  "OK. Generated",
+		},
+		{
+			expected:  &reflectOrder{},
+			actual:    &Order{},
+			precision: 2,
+			testName:  "OK. Zero struct",
+		},
+		{
+			expected:  &reflectEvent{Payload: payload, Values: []float64{1.555}},
+			actual:    &Event{Payload: payload, Values: []float64{1.555}},
+			precision: 1,
+			testName:  "OK. Reflection for interface fields",
+		},
+		{
+			expected:  &reflectNode{Value: 1.555, Next: &Node{Value: 2.555}},
+			actual:    &Node{Value: 1.555, Next: &Node{Value: 2.555}},
+			precision: 1,
+			testName:  "OK. Reflection for recursive struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("attrsgen")
+			t.Description("Check generated `RoundFloatFields` is the same as reflection")
+			t.WithParameters(allure.NewParameter("precision", testCase.precision))
+
+			t.Require().NoError(attrs.RoundStructFloatFields(testCase.expected, testCase.precision))
+			t.Require().NoError(attrs.RoundStructFloatFields(testCase.actual, testCase.precision))
+			t.Assert().EqualValues(testCase.expected, testCase.actual, "Check RoundStructFloatFields")
+		})
+	}
+
+	runner.Run(t, "OK. Rounded values", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("attrsgen")
+		t.Description("Check generated `RoundFloatFields` values")
+
+		order := newOrder()
+		t.Require().NoError(order.RoundFloatFields(2))
+
+		t.Assert().Equal(10.56, order.Customer.Balance)
+		t.Assert().Equal(1.56, order.Customer.Items[0].Price)
+		t.Assert().Equal([]float64{1.3, 2.4}, order.Prices)
+		t.Assert().Equal(1.24, *order.Rate)
+		t.Assert().Equal(float32(3.14), order.Total)
+		t.Assert().Equal(Percent(0.2), order.Discount)
+		t.Assert().Equal(3.333, order.Stock["a"].Price)
+		t.Assert().Equal([]float64{1.11, 2.23}, order.Grid[0])
+		t.Assert().Equal(9.999, order.Skip)
+	})
+}
+
+func TestToMap(t *testing.T) {
+	withAudit := newOrder()
+	withAudit.Audit = &Audit{CreatedBy: "admin"}
+
+	testCases := []struct {
+		expected interface{}
+		actual   interface{}
+		testName string
+	}{
+		{expected: reflectOrder(newOrder()), actual: newOrder(), testName: "OK. Struct"},
+		{expected: reflectOrder(withAudit), actual: &withAudit, testName: "OK. Ptr struct"},
+		{expected: reflectOrder{}, actual: Order{}, testName: "OK. Zero struct"},
+		{
+			expected: reflectNode{Value: 1, Next: &Node{Value: 2}},
+			actual:   Node{Value: 1, Next: &Node{Value: 2}},
+			testName: "OK. Recursive struct",
+		},
+		{
+			expected: reflectEvent{Name: "e", Payload: Item{SKU: "a"}},
+			actual:   Event{Name: "e", Payload: Item{SKU: "a"}},
+			testName: "OK. Interface fields",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("attrsgen")
+			t.Description("Check generated `ToMap` is the same as reflection")
+
+			expected, err := attrs.ToMap(testCase.expected)
+			t.Require().NoError(err)
+
+			actual, err := attrs.ToMap(testCase.actual)
+			t.Require().NoError(err)
+			t.Assert().Equal(expected, actual, "Check ToMap")
+		})
+	}
+}
+
+// assertSameError - generated and reflection errors are the same.
+func assertSameError(t provider.T, expected, actual error, name string) {
+	if expected == nil {
+		t.Assert().NoError(actual, name)
+		return
+	}
+
+	t.Assert().EqualError(actual, expected.Error(), name)
+}
@@ -0,0 +1,178 @@
+package attrs
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// WithDottedKeys - ToMap puts nested struct fields by dotted keys like `Address.City` instead of nested maps.
+func WithDottedKeys() Option {
+	return func(o *options) {
+		o.dottedKeys = true
+	}
+}
+
+// ToMap - struct fields to map by field names. Ptr fields are dereferenced, nil ptrs are nil values,
+// unexported fields are skipped. Nested structs are nested maps, structs without exported fields
+// (time.Time, ...), slices and maps are kept as values.
+// 'obj': struct or ptr struct.
+// 'opts': optional, WithDottedKeys for flat map, WithTagKey for tag names. Generated Mapper is used without opts.
+func ToMap(obj interface{}, opts ...Option) (map[string]interface{}, error) {
+	objValue := reflect.ValueOf(obj)
+	// ptr struct check
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		objValue = objValue.Elem()
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return nil, objError(opToMap, ErrNotStruct, objValue)
+	}
+	// generated ToMap check
+	if len(opts) == 0 && isGenerated(reflect.TypeOf(obj), genMapper) {
+		m, err := obj.(Mapper).ToMap()
+		if err != nil {
+			return nil, opError(opToMap, err)
+		}
+
+		return m, nil
+	}
+
+	var (
+		o   = newOptions(opts)
+		out = make(map[string]interface{})
+	)
+
+	structToMap(objValue, "", out, o)
+
+	return out, nil
+}
+
+// structToMap - put struct value fields to map.
+func structToMap(v reflect.Value, prefix string, out map[string]interface{}, o *options) {
+	fields := structFields(v.Type(), o)
+	for i := range fields {
+		f := &fields[i]
+		// is field exported
+		if !f.exported {
+			continue
+		}
+
+		key := f.name
+		if o.dottedKeys {
+			key = joinName(prefix, f.name)
+		}
+		// is embedded ptr nil check
+		field, err := fieldByInfo(v, f, false)
+		if err != nil {
+			out[key] = nil
+			continue
+		}
+		// is ptr && is ptr nil check
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				out[key] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		// nested struct
+		if field.Kind() == reflect.Struct && hasExported(structFields(field.Type(), o)) {
+			if o.dottedKeys {
+				structToMap(field, key, out, o)
+				continue
+			}
+
+			nested := make(map[string]interface{})
+			structToMap(field, "", nested, o)
+			out[key] = nested
+
+			continue
+		}
+
+		out[key] = field.Interface()
+	}
+}
+
+// FromMap - set struct fields from map by SetAttr. Keys are field names or paths like `Address.City`,
+// nested maps `map[string]interface{}` fill nested structs, nil ptrs are allocated, nil values set zero values.
+// 'obj': ptr struct.
+// 'm': map of field names to values.
+// 'opts': optional, WithConvert to convert values, WithTagKey for tag names, WithIgnoreMissing to skip unknown keys.
+func FromMap(obj interface{}, m map[string]interface{}, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr {
+		return objError(opFromMap, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opFromMap, ErrNotStruct, objValue)
+	}
+
+	if err := mapToStruct(objValue.Elem(), m, newOptions(opts)); err != nil {
+		return opError(opFromMap, err)
+	}
+
+	return nil
+}
+
+// mapToStruct - set addressable struct value fields from map in keys order.
+func mapToStruct(v reflect.Value, m map[string]interface{}, o *options) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		err := mapValueToField(v, key, m[key], o)
+		if err != nil && !(o.merge.ignoreMissing && errors.Is(err, ErrFieldNotInStruct)) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapValueToField - set one map value to struct field by key.
+func mapValueToField(v reflect.Value, key string, value interface{}, o *options) error {
+	switch value := value.(type) {
+	case nil:
+		// nil value: zero field, nothing to do if its parent is nil
+		field, err := getField(v, key, o)
+		if errors.Is(err, ErrNilPointer) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return setField(v, key, reflect.Zero(field.Type()), o)
+	case map[string]interface{}:
+		// nested map to nested struct
+		var (
+			field reflect.Value
+			err   error
+		)
+
+		if isFieldName(key) {
+			field, err = structField(v, key, true, o)
+		} else {
+			field, err = getField(v, key, o)
+		}
+
+		if err == nil && derefKind(field.Type()) == reflect.Struct && field.CanSet() {
+			nested, _ := derefAlloc(field, reflect.Struct)
+			if err := mapToStruct(nested, value, o); err != nil {
+				return prefixError(err, key)
+			}
+
+			return nil
+		}
+	}
+
+	return setField(v, key, reflect.ValueOf(value), o)
+}
@@ -0,0 +1,268 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type mapAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type mapUser struct {
+	Name     string            `json:"name"`
+	Age      *int              `json:"age"`
+	Address  mapAddress        `json:"address"`
+	Billing  *mapAddress       `json:"billing"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created"`
+	Password string            `json:"-"`
+	secret   string
+}
+
+func TestToMap(t *testing.T) {
+	age := 30
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := mapUser{
+		Name:     "name",
+		Age:      &age,
+		Address:  mapAddress{City: "city", Street: "street"},
+		Tags:     []string{"a"},
+		Labels:   map[string]string{"env": "prod"},
+		Created:  created,
+		Password: "password",
+		secret:   "secret",
+	}
+
+	testCases := []struct {
+		obj         interface{}
+		opts        []Option
+		expected    map[string]interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: user,
+			expected: map[string]interface{}{
+				"Name":     "name",
+				"Age":      30,
+				"Address":  map[string]interface{}{"City": "city", "Street": "street"},
+				"Billing":  nil,
+				"Tags":     []string{"a"},
+				"Labels":   map[string]string{"env": "prod"},
+				"Created":  created,
+				"Password": "password",
+			},
+			testName: "OK. Nested maps",
+		},
+		{
+			obj:  &user,
+			opts: []Option{WithDottedKeys()},
+			expected: map[string]interface{}{
+				"Name":           "name",
+				"Age":            30,
+				"Address.City":   "city",
+				"Address.Street": "street",
+				"Billing":        nil,
+				"Tags":           []string{"a"},
+				"Labels":         map[string]string{"env": "prod"},
+				"Created":        created,
+				"Password":       "password",
+			},
+			testName: "OK. Dotted keys, ptr struct",
+		},
+		{
+			obj:  user,
+			opts: []Option{WithTagKey("json"), WithDottedKeys()},
+			expected: map[string]interface{}{
+				"name":           "name",
+				"age":            30,
+				"address.city":   "city",
+				"address.street": "street",
+				"billing":        nil,
+				"tags":           []string{"a"},
+				"labels":         map[string]string{"env": "prod"},
+				"created":        created,
+			},
+			testName: "OK. Tag names",
+		},
+		{
+			obj:         "not struct",
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("ToMap")
+			t.Description("Check func `ToMap`")
+			t.WithParameters(
+				allure.NewParameter("obj", testCase.obj),
+			)
+
+			actual, err := ToMap(testCase.obj, testCase.opts...)
+			if err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("ToMap error: %v", err))
+				return
+			}
+
+			t.Assert().Equal(testCase.expected, actual, "Check ToMap")
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	age := 30
+
+	testCases := []struct {
+		obj         interface{}
+		m           map[string]interface{}
+		opts        []Option
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: &mapUser{Name: "old", Billing: &mapAddress{City: "old"}},
+			m: map[string]interface{}{
+				"Name":    "name",
+				"Age":     30,
+				"Address": map[string]interface{}{"City": "city"},
+				"Billing": nil,
+				"Tags":    []string{"a"},
+			},
+			expected: &mapUser{Name: "name", Age: &age, Address: mapAddress{City: "city"}, Tags: []string{"a"}},
+			testName: "OK. Nested map, nil value",
+		},
+		{
+			obj: &mapUser{},
+			m: map[string]interface{}{
+				"Billing.City":  "city",
+				`Labels["env"]`: "prod",
+			},
+			expected: &mapUser{Billing: &mapAddress{City: "city"}, Labels: map[string]string{"env": "prod"}},
+			testName: "OK. Dotted keys allocate ptrs and maps",
+		},
+		{
+			obj: &mapUser{},
+			m: map[string]interface{}{
+				"name":    "name",
+				"age":     "30",
+				"billing": map[string]interface{}{"city": "city"},
+			},
+			opts:     []Option{WithTagKey("json"), WithConvert()},
+			expected: &mapUser{Name: "name", Age: &age, Billing: &mapAddress{City: "city"}},
+			testName: "OK. Tag names with conversion",
+		},
+		{
+			obj:      &mapUser{},
+			m:        map[string]interface{}{"Name": "name", "Unknown": 1, "Address": map[string]interface{}{"Zip": 1}},
+			opts:     []Option{WithIgnoreMissing()},
+			expected: &mapUser{Name: "name"},
+			testName: "OK. Ignore missing keys",
+		},
+		{
+			obj:         &mapUser{},
+			m:           map[string]interface{}{"Address": map[string]interface{}{"Zip": 1}},
+			expected:    &mapUser{},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Missing nested key",
+		},
+		{
+			obj:         &mapUser{},
+			m:           map[string]interface{}{"Age": "30"},
+			expected:    &mapUser{},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong value type without conversion",
+		},
+		{
+			obj:         mapUser{},
+			expected:    mapUser{},
+			expectedErr: ErrNotPointerStruct,
+			testName:    "ERR. Struct passed not by pointer",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("FromMap")
+			t.Description("Check func `FromMap`")
+			t.WithParameters(
+				allure.NewParameter("map", testCase.m),
+			)
+
+			err := FromMap(testCase.obj, testCase.m, testCase.opts...)
+			if err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("FromMap error: %v", err))
+			}
+
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check FromMap")
+		})
+	}
+}
+
+func TestFromMapErrorPath(t *testing.T) {
+	runner.Run(t, "ERR. Path of nested key", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("FromMap")
+		t.Description("Check path of `FromMap` error")
+
+		err := FromMap(&mapUser{}, map[string]interface{}{"Billing": map[string]interface{}{"City": 1}})
+		t.Assert().EqualError(err, "FromMap Billing.City: wrong field value type (expected string, got int)")
+	})
+}
+
+func ExampleToMap() {
+	type Address struct {
+		City string `json:"city"`
+	}
+
+	type User struct {
+		Name    string   `json:"name"`
+		Address *Address `json:"address"`
+	}
+
+	m, err := ToMap(User{Name: "name", Address: &Address{City: "city"}}, WithTagKey("json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(m)
+
+	flat, err := ToMap(User{Name: "name", Address: &Address{City: "city"}}, WithTagKey("json"), WithDottedKeys())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(flat)
+
+	user := &User{}
+	if err := FromMap(user, flat, WithTagKey("json")); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(user.Name, user.Address.City)
+	// Output:
+	// map[address:map[city:city] name:name]
+	// map[address.city:city name:name]
+	// name city
+}
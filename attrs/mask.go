@@ -0,0 +1,400 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// fieldMask - tree of field mask paths by field names, nil subtree is the whole field.
+type fieldMask map[string]fieldMask
+
+// newFieldMask - tree of dotted paths like `customer.name`. Path of the whole field wins over its subpaths.
+func newFieldMask(paths []string) (fieldMask, error) {
+	root := make(fieldMask, len(paths))
+
+	for _, path := range paths {
+		names := strings.Split(path, ".")
+		node := root
+
+		for i, name := range names {
+			// empty name check
+			if name == "" {
+				return nil, &AttrError{Path: path, Err: ErrInvalidPath}
+			}
+			// last name: whole field
+			if i == len(names)-1 {
+				node[name] = nil
+				break
+			}
+
+			next, ok := node[name]
+			if ok && next == nil {
+				// whole field is already masked
+				break
+			}
+
+			if !ok {
+				next = make(fieldMask)
+				node[name] = next
+			}
+
+			node = next
+		}
+	}
+
+	return root, nil
+}
+
+// Project - copy of struct with only masked fields, other fields are zero, like protobuf FieldMask.
+// Mask paths are dotted field names like `customer.name`, through slices and maps they mask fields of
+// every element: `items.price`. Masked values are deep-copied by Clone.
+// 'obj': struct or ptr struct, for ptr a ptr to new struct is returned.
+// 'mask': field paths.
+// 'opts': optional, WithTagKey for tag names in paths.
+func Project[T any](obj T, mask []string, opts ...Option) (T, error) {
+	var (
+		out      T
+		objValue = reflect.ValueOf(obj)
+	)
+	// ptr struct check
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		projected := reflect.New(objValue.Type().Elem())
+		if err := maskedCopy(projected.Elem(), objValue.Elem(), mask, newOptions(opts)); err != nil {
+			return out, opError(opProject, err)
+		}
+
+		return projected.Interface().(T), nil
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return out, objError(opProject, ErrNotStruct, objValue)
+	}
+
+	projected := reflect.New(objValue.Type()).Elem()
+	if err := maskedCopy(projected, objValue, mask, newOptions(opts)); err != nil {
+		return out, opError(opProject, err)
+	}
+
+	return projected.Interface().(T), nil
+}
+
+// ProjectMap - masked fields of struct to map like ToMap, other fields are not in map.
+// Masked slices and maps of structs are `[]interface{}` and `map[string]interface{}` of masked element fields.
+// 'obj': struct or ptr struct.
+// 'mask': field paths.
+// 'opts': optional, WithTagKey for tag names in paths and keys.
+func ProjectMap(obj interface{}, mask []string, opts ...Option) (map[string]interface{}, error) {
+	objValue := reflect.ValueOf(obj)
+	// ptr struct check
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		objValue = objValue.Elem()
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return nil, objError(opProject, ErrNotStruct, objValue)
+	}
+
+	m, err := newFieldMask(mask)
+	if err != nil {
+		return nil, opError(opProject, err)
+	}
+
+	out, err := projectValue(objValue, m, newOptions(opts))
+	if err != nil {
+		return nil, opError(opProject, err)
+	}
+
+	return out.(map[string]interface{}), nil
+}
+
+// MaskedCopy - copy only masked fields from src to dst, other dst fields are kept, like protobuf FieldMask merge.
+// Through slices and maps masked fields of every element are copied, dst is resized to src length and keys.
+// Nil src ptrs set dst ptrs to nil. Copied values are deep-copied by Clone.
+// 'dst': ptr struct.
+// 'src': struct or ptr struct of the same type.
+// 'mask': field paths.
+// 'opts': optional, WithTagKey for tag names in paths.
+func MaskedCopy(dst, src interface{}, mask []string, opts ...Option) error {
+	dstValue, srcValue := reflect.ValueOf(dst), reflect.ValueOf(src)
+	// struct ptr check
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return objError(opMaskedCopy, ErrNotPointerStruct, dstValue)
+	}
+	// src ptr struct check
+	if srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() {
+		srcValue = srcValue.Elem()
+	}
+	// same types check
+	if dstValue.Elem().Type() != valueType(srcValue) {
+		return &AttrError{
+			Op:       opMaskedCopy,
+			Err:      ErrWrongFieldValueType,
+			Expected: dstValue.Elem().Type(),
+			Actual:   valueType(srcValue),
+		}
+	}
+
+	if err := maskedCopy(dstValue.Elem(), srcValue, mask, newOptions(opts)); err != nil {
+		return opError(opMaskedCopy, err)
+	}
+
+	return nil
+}
+
+// maskedCopy - copy masked fields of src struct value to addressable dst struct value of the same type.
+func maskedCopy(dst, src reflect.Value, mask []string, o *options) error {
+	// is struct check
+	if src.Kind() != reflect.Struct {
+		return objError("", ErrNotStruct, src)
+	}
+
+	m, err := newFieldMask(mask)
+	if err != nil {
+		return err
+	}
+
+	c := &masker{o: o, c: &cloner{o: defaultOptions}}
+
+	return c.copy(dst, src, m)
+}
+
+// masker - copies masked fields, deep copies values by cloner.
+type masker struct {
+	o *options
+	c *cloner
+}
+
+// copy - copy src value to dst by mask. Whole value is copied for nil mask.
+func (c *masker) copy(dst, src reflect.Value, m fieldMask) error {
+	if m == nil {
+		dst.Set(c.c.clone(src))
+		return nil
+	}
+
+	switch src.Kind() {
+	case reflect.Struct:
+		return c.copyStruct(dst, src, m)
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return c.copy(dst.Elem(), src.Elem(), m)
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		// interface elem is not addressable: copy to dst elem copy of the same type
+		elem := reflect.New(src.Elem().Type()).Elem()
+		if !dst.IsNil() && dst.Elem().Type() == elem.Type() {
+			elem.Set(dst.Elem())
+		}
+
+		if err := c.copy(elem, src.Elem(), m); err != nil {
+			return err
+		}
+
+		dst.Set(elem)
+	case reflect.Slice, reflect.Array:
+		if src.Kind() == reflect.Slice {
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			// dst elements are kept up to src length
+			resized := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			reflect.Copy(resized, dst)
+			dst.Set(resized)
+		}
+
+		for i := 0; i < src.Len(); i++ {
+			if err := c.copy(dst.Index(i), src.Index(i), m); err != nil {
+				return prefixError(err, indexPath("", i))
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		// dst elements are kept for src keys
+		out := reflect.MakeMapWithSize(dst.Type(), src.Len())
+
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if cur := dst.MapIndex(iter.Key()); cur.IsValid() {
+				elem.Set(cur)
+			}
+
+			if err := c.copy(elem, iter.Value(), m); err != nil {
+				return prefixError(err, keySegment(iter.Key()).String())
+			}
+
+			out.SetMapIndex(iter.Key(), elem)
+		}
+
+		dst.Set(out)
+	default:
+		return typeError(ErrNotStruct, nil, src.Type())
+	}
+
+	return nil
+}
+
+// copyStruct - copy masked fields of src struct to dst struct.
+func (c *masker) copyStruct(dst, src reflect.Value, m fieldMask) error {
+	for _, name := range sortedNames(m) {
+		f, err := maskField(src.Type(), name, c.o)
+		if err != nil {
+			return err
+		}
+		// embedded nil ptr in src: zero value
+		srcField, err := fieldByInfo(src, f, false)
+		if err != nil {
+			srcField = reflect.Zero(f.typ)
+		}
+
+		dstField, err := fieldByInfo(dst, f, true)
+		if err != nil {
+			return &AttrError{Path: name, Err: err}
+		}
+
+		if err := c.copy(dstField, srcField, m[name]); err != nil {
+			return prefixError(err, name)
+		}
+	}
+
+	return nil
+}
+
+// projectValue - masked value for ProjectMap: maps of masked struct fields.
+func projectValue(v reflect.Value, m fieldMask, o *options) (interface{}, error) {
+	// whole value like ToMap
+	if m == nil {
+		return mapValue(v, o), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, len(m))
+
+		for _, name := range sortedNames(m) {
+			f, err := maskField(v.Type(), name, o)
+			if err != nil {
+				return nil, err
+			}
+			// embedded nil ptr: nil value
+			field, err := fieldByInfo(v, f, false)
+			if err != nil {
+				out[name] = nil
+				continue
+			}
+
+			value, err := projectValue(field, m[name], o)
+			if err != nil {
+				return nil, prefixError(err, name)
+			}
+
+			out[name] = value
+		}
+
+		return out, nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return projectValue(v.Elem(), m, o)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			value, err := projectValue(v.Index(i), m, o)
+			if err != nil {
+				return nil, prefixError(err, indexPath("", i))
+			}
+
+			out[i] = value
+		}
+
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		out := make(map[string]interface{}, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := projectValue(iter.Value(), m, o)
+			if err != nil {
+				return nil, prefixError(err, keySegment(iter.Key()).String())
+			}
+
+			out[fmt.Sprint(iter.Key().Interface())] = value
+		}
+
+		return out, nil
+	default:
+		return nil, typeError(ErrNotStruct, nil, v.Type())
+	}
+}
+
+// mapValue - value like in ToMap: ptrs dereferenced, nested structs to maps.
+func mapValue(v reflect.Value, o *options) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct && hasExported(structFields(v.Type(), o)) {
+		nested := make(map[string]interface{})
+		structToMap(v, "", nested, o)
+
+		return nested
+	}
+
+	return v.Interface()
+}
+
+// maskField - exported struct field by mask name.
+func maskField(t reflect.Type, name string, o *options) (*fieldInfo, error) {
+	f, ok := lookupField(t, name, o)
+	// is field in struct
+	if !ok {
+		return nil, &AttrError{Path: name, Err: ErrFieldNotInStruct}
+	}
+	// is field exported
+	if !f.exported {
+		return nil, &AttrError{Path: name, Err: ErrUnexportedField}
+	}
+
+	return f, nil
+}
+
+// sortedNames - mask names in sorted order, so errors are stable.
+func sortedNames(m fieldMask) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,316 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type maskCustomer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type maskItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+type maskOrder struct {
+	ID       int                 `json:"id"`
+	Customer *maskCustomer       `json:"customer"`
+	Items    []maskItem          `json:"items"`
+	Stock    map[string]maskItem `json:"stock"`
+	Tags     []string            `json:"tags"`
+	Any      interface{}         `json:"any"`
+	note     string
+}
+
+func TestProject(t *testing.T) {
+	var (
+		order = maskOrder{
+			ID:       1,
+			Customer: &maskCustomer{Name: "john", Email: "john@example.com"},
+			Items:    []maskItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+			Stock:    map[string]maskItem{"a": {SKU: "a", Price: 3}},
+			Tags:     []string{"new"},
+			Any:      maskItem{SKU: "any", Price: 4},
+			note:     "note",
+		}
+		original = maskOrder{
+			ID:       1,
+			Customer: &maskCustomer{Name: "john", Email: "john@example.com"},
+			Items:    []maskItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+			Stock:    map[string]maskItem{"a": {SKU: "a", Price: 3}},
+			Tags:     []string{"new"},
+			Any:      maskItem{SKU: "any", Price: 4},
+			note:     "note",
+		}
+	)
+
+	testCases := []struct {
+		mask        []string
+		opts        []Option
+		expected    maskOrder
+		expectedErr error
+		testName    string
+	}{
+		{
+			mask: []string{"ID", "Customer.Name", "Items.Price", "Stock.SKU", "Tags", "Any.Price"},
+			expected: maskOrder{
+				ID:       1,
+				Customer: &maskCustomer{Name: "john"},
+				Items:    []maskItem{{Price: 1.5}, {Price: 2.5}},
+				Stock:    map[string]maskItem{"a": {SKU: "a"}},
+				Tags:     []string{"new"},
+				Any:      maskItem{Price: 4},
+			},
+			testName: "OK. Nested paths",
+		},
+		{
+			mask: []string{"customer.email", "customer", "items.sku"},
+			opts: []Option{WithTagKey("json")},
+			expected: maskOrder{
+				Customer: &maskCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []maskItem{{SKU: "a"}, {SKU: "b"}},
+			},
+			testName: "OK. Tag names, whole field wins",
+		},
+		{
+			mask:     nil,
+			expected: maskOrder{},
+			testName: "OK. Empty mask",
+		},
+		{
+			mask:        []string{"Customer.Phone"},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			mask:        []string{"note"},
+			expectedErr: ErrUnexportedField,
+			testName:    "ERR. Unexported field",
+		},
+		{
+			mask:        []string{"Tags.Name"},
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Path through not a struct",
+		},
+		{
+			mask:        []string{"Customer..Name"},
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Invalid path",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Project")
+			t.Description("Check func `Project` by value and by ptr")
+			t.WithParameters(allure.NewParameter("mask", testCase.mask))
+
+			projected, err := Project(order, testCase.mask, testCase.opts...)
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Project error: %v", err))
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, projected, "Check Project")
+
+			ptr, err := Project(&order, testCase.mask, testCase.opts...)
+			t.Require().NoError(err)
+			t.Assert().Equal(&testCase.expected, ptr, "Check Project by ptr")
+			t.Assert().Equal(original, order, "Check obj not changed")
+		})
+	}
+}
+
+func TestProjectMap(t *testing.T) {
+	runner.Run(t, "OK. Masked paths", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ProjectMap")
+		t.Description("Check func `ProjectMap`")
+
+		order := maskOrder{
+			ID:       1,
+			Customer: &maskCustomer{Name: "john", Email: "john@example.com"},
+			Items:    []maskItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+			Stock:    map[string]maskItem{"a": {SKU: "a", Price: 3}},
+			Tags:     []string{"new"},
+			Any:      maskItem{SKU: "any", Price: 4},
+			note:     "note",
+		}
+
+		m, err := ProjectMap(order, []string{"id", "customer", "items.price", "stock.sku"}, WithTagKey("json"))
+		t.Require().NoError(err)
+		t.Assert().Equal(map[string]interface{}{
+			"id":       1,
+			"customer": map[string]interface{}{"name": "john", "email": "john@example.com"},
+			"items":    []interface{}{map[string]interface{}{"price": 1.5}, map[string]interface{}{"price": 2.5}},
+			"stock":    map[string]interface{}{"a": map[string]interface{}{"sku": "a"}},
+		}, m)
+	})
+
+	runner.Run(t, "OK. Nil values", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ProjectMap")
+		t.Description("Check func `ProjectMap` with nil ptrs and slices")
+
+		m, err := ProjectMap(&maskOrder{}, []string{"Customer.Name", "Items.Price", "Any"})
+		t.Require().NoError(err)
+		t.Assert().Equal(map[string]interface{}{"Customer": nil, "Items": nil, "Any": nil}, m)
+	})
+
+	runner.Run(t, "ERR. Not a struct", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ProjectMap")
+		t.Description("Check func `ProjectMap` errors")
+
+		_, err := ProjectMap(1, []string{"ID"})
+		t.Assert().ErrorIs(err, ErrNotStruct)
+
+		_, err = ProjectMap(maskOrder{Items: []maskItem{{SKU: "a"}}}, []string{"Items.Price.Value"})
+		t.Assert().ErrorIs(err, ErrNotStruct)
+		t.Assert().EqualError(err, "Project Items[0].Price: not a struct (got float64)")
+	})
+}
+
+func TestMaskedCopy(t *testing.T) {
+	testCases := []struct {
+		dst         interface{}
+		src         interface{}
+		mask        []string
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			dst: &maskOrder{
+				ID:       2,
+				Customer: &maskCustomer{Name: "old", Email: "old@example.com"},
+				Items:    []maskItem{{SKU: "old", Price: 1}},
+				Stock:    map[string]maskItem{"a": {SKU: "old"}, "b": {SKU: "b"}},
+				note:     "dst",
+			},
+			src: maskOrder{
+				ID:       1,
+				Customer: &maskCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []maskItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Stock:    map[string]maskItem{"a": {SKU: "a", Price: 3}},
+				Tags:     []string{"new"},
+				Any:      maskItem{SKU: "any", Price: 4},
+				note:     "note",
+			},
+			mask: []string{"Customer.Name", "Items.Price", "Stock.Price"},
+			expected: &maskOrder{
+				ID:       2,
+				Customer: &maskCustomer{Name: "john", Email: "old@example.com"},
+				Items:    []maskItem{{SKU: "old", Price: 1.5}, {Price: 2.5}},
+				Stock:    map[string]maskItem{"a": {SKU: "old", Price: 3}},
+				note:     "dst",
+			},
+			testName: "OK. Merge masked paths",
+		},
+		{
+			dst:      &maskOrder{Customer: &maskCustomer{Name: "old"}, Items: []maskItem{{SKU: "old"}}},
+			src:      &maskOrder{},
+			mask:     []string{"Customer.Name", "Items.SKU"},
+			expected: &maskOrder{},
+			testName: "OK. Nil src values",
+		},
+		{
+			dst:         maskOrder{},
+			src:         maskOrder{},
+			mask:        []string{"ID"},
+			expectedErr: ErrNotPointerStruct,
+			testName:    "ERR. Dst not a ptr",
+		},
+		{
+			dst:         &maskOrder{},
+			src:         maskItem{},
+			mask:        []string{"ID"},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Different types",
+		},
+		{
+			dst:         &maskOrder{},
+			src:         maskOrder{},
+			mask:        []string{"Unknown"},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("MaskedCopy")
+			t.Description("Check func `MaskedCopy`")
+			t.WithParameters(allure.NewParameter("mask", testCase.mask))
+
+			err := MaskedCopy(testCase.dst, testCase.src, testCase.mask)
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("MaskedCopy error: %v", err))
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.dst, "Check MaskedCopy")
+		})
+	}
+}
+
+func ExampleProject() {
+	type Customer struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	type Item struct {
+		SKU   string  `json:"sku"`
+		Price float64 `json:"price"`
+	}
+
+	type Order struct {
+		ID       int       `json:"id"`
+		Customer *Customer `json:"customer"`
+		Items    []Item    `json:"items"`
+	}
+
+	order := Order{
+		ID:       1,
+		Customer: &Customer{Name: "john", Email: "john@example.com"},
+		Items:    []Item{{SKU: "a", Price: 1.5}},
+	}
+	mask := []string{"id", "customer.name", "items.price"}
+
+	projected, err := Project(order, mask, WithTagKey("json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m, err := ProjectMap(order, mask, WithTagKey("json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(projected.ID, *projected.Customer, projected.Items)
+	fmt.Println(m)
+	// Output:
+	// 1 {john } [{ 1.5}]
+	// map[customer:map[name:john] id:1 items:[map[price:1.5]]]
+}
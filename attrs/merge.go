@@ -0,0 +1,318 @@
+package attrs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// mergeOptions - SetStructAttrs merge policies.
+type mergeOptions struct {
+	skipZero      bool                // skip zero values of new struct fields
+	keepExisting  bool                // keep non-zero values of current struct fields
+	appendSlices  bool                // append new slices to current ones
+	mergeMaps     bool                // merge new maps into current ones
+	deep          bool                // recurse into nested structs
+	ignoreMissing bool                // skip new struct fields absent in current struct
+	allow         map[string]struct{} // field paths to merge, all if empty
+	deny          map[string]struct{} // field paths not to merge
+}
+
+// WithSkipZero - SetStructAttrs skips zero values of new struct fields, not only nil pointers.
+func WithSkipZero() Option {
+	return func(o *options) {
+		o.merge.skipZero = true
+	}
+}
+
+// WithKeepExisting - SetStructAttrs keeps non-zero values of current struct fields.
+func WithKeepExisting() Option {
+	return func(o *options) {
+		o.merge.keepExisting = true
+	}
+}
+
+// WithAppendSlices - SetStructAttrs appends new slices to current ones instead of replacing.
+func WithAppendSlices() Option {
+	return func(o *options) {
+		o.merge.appendSlices = true
+	}
+}
+
+// WithMergeMaps - SetStructAttrs adds new map entries to current maps instead of replacing maps.
+// With WithKeepExisting existing keys are not overwritten.
+func WithMergeMaps() Option {
+	return func(o *options) {
+		o.merge.mergeMaps = true
+	}
+}
+
+// WithDeepMerge - SetStructAttrs recurses into nested structs and struct pointers instead of replacing them.
+// Structs with unexported fields (time.Time, ...) are replaced as a whole.
+func WithDeepMerge() Option {
+	return func(o *options) {
+		o.merge.deep = true
+	}
+}
+
+// WithIgnoreMissing - SetStructAttrs skips new struct fields absent in current struct,
+// so structs of different but overlapping types can be merged. FromMap skips keys absent in struct.
+func WithIgnoreMissing() Option {
+	return func(o *options) {
+		o.merge.ignoreMissing = true
+	}
+}
+
+// WithFields - SetStructAttrs merges only listed fields. Dotted paths select nested fields with WithDeepMerge.
+func WithFields(paths ...string) Option {
+	return func(o *options) {
+		o.merge.allow = addPaths(o.merge.allow, paths)
+	}
+}
+
+// WithoutFields - SetStructAttrs does not merge listed fields and their nested fields.
+func WithoutFields(paths ...string) Option {
+	return func(o *options) {
+		o.merge.deny = addPaths(o.merge.deny, paths)
+	}
+}
+
+// addPaths - add paths to set.
+func addPaths(set map[string]struct{}, paths []string) map[string]struct{} {
+	if set == nil {
+		set = make(map[string]struct{}, len(paths))
+	}
+
+	for _, path := range paths {
+		set[path] = struct{}{}
+	}
+
+	return set
+}
+
+// selected - check field path by allow and deny lists.
+// 'full': field is selected itself, 'partial': only some of its nested fields are selected.
+func (m *mergeOptions) selected(path string) (full, partial bool) {
+	// field or its parent denied
+	for p := path; ; {
+		if _, ok := m.deny[p]; ok {
+			return false, false
+		}
+
+		i := strings.LastIndexByte(p, '.')
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+
+	if len(m.allow) == 0 {
+		return true, false
+	}
+	// field or its parent allowed
+	for p := path; ; {
+		if _, ok := m.allow[p]; ok {
+			return true, false
+		}
+
+		i := strings.LastIndexByte(p, '.')
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	// nested field allowed
+	for p := range m.allow {
+		if strings.HasPrefix(p, path+".") {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// joinName - join parent path and field name.
+func joinName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// mergeStruct - set current struct fields from new struct fields with the same names by merge policies.
+func mergeStruct(curValue, newValue reflect.Value, prefix string, o *options) error {
+	fields := structFields(newValue.Type(), o)
+	for i := range fields {
+		f := &fields[i]
+		path := joinName(prefix, f.name)
+		// allow and deny lists check
+		full, partial := o.merge.selected(path)
+		if !full && !partial {
+			continue
+		}
+		// is field exported
+		if !f.exported {
+			return &AttrError{Path: path, Err: ErrUnexportedField}
+		}
+		// get new struct field value, skip nil embedded ptr
+		field, err := fieldByInfo(newValue, f, false)
+		if err != nil {
+			continue
+		}
+		// is ptr && is ptr nil check
+		if f.kind == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		// zero value check
+		if o.merge.skipZero && field.IsZero() {
+			continue
+		}
+		// get current struct field
+		curField, err := structField(curValue, f.name, true, o)
+		if err != nil {
+			if o.merge.ignoreMissing && errors.Is(err, ErrFieldNotInStruct) {
+				continue
+			}
+
+			return &AttrError{Path: path, Err: err}
+		}
+
+		if err := mergeValue(curField, field, path, full, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeValue - merge new value into current field by merge policies.
+func mergeValue(curField, newValue reflect.Value, path string, full bool, o *options) error {
+	// nested structs
+	if o.merge.deep && isMergeStruct(newValue.Type()) {
+		if cur, ok := derefAlloc(curField, reflect.Struct); ok && isMergeStruct(cur.Type()) {
+			return mergeStruct(cur, newValue, path, o)
+		}
+	}
+
+	if !full {
+		return nil
+	}
+
+	var err error
+
+	switch {
+	case o.merge.mergeMaps && newValue.Kind() == reflect.Map && derefKind(curField.Type()) == reflect.Map:
+		cur, _ := derefAlloc(curField, reflect.Map)
+		err = mergeMap(cur, newValue, o)
+	case o.merge.appendSlices && newValue.Kind() == reflect.Slice && derefKind(curField.Type()) == reflect.Slice:
+		cur, _ := derefAlloc(curField, reflect.Slice)
+		err = appendSlice(cur, newValue, o)
+	case o.merge.keepExisting && !curField.IsZero():
+		return nil
+	default:
+		err = assign(curField, newValue, o)
+	}
+
+	if err != nil {
+		return fieldError("", path, err)
+	}
+
+	return nil
+}
+
+// isMergeStruct - struct type without unexported fields, can be merged field by field.
+func isMergeStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && getTypeInfo(t).allExported
+}
+
+// derefKind - kind of type, dereferenced if ptr.
+func derefKind(t reflect.Type) reflect.Kind {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem().Kind()
+	}
+
+	return t.Kind()
+}
+
+// derefAlloc - dereference addressable ptr field to value of kind, nil ptr is allocated.
+func derefAlloc(v reflect.Value, kind reflect.Kind) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == kind {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	return v, v.Kind() == kind
+}
+
+// mergeMap - add new map entries to current map. Existing keys are kept with WithKeepExisting.
+func mergeMap(cur, newMap reflect.Value, o *options) error {
+	if newMap.Len() == 0 {
+		return nil
+	}
+
+	if cur.IsNil() {
+		cur.Set(reflect.MakeMapWithSize(cur.Type(), newMap.Len()))
+	}
+
+	iter := newMap.MapRange()
+	for iter.Next() {
+		key, err := valueTo(iter.Key(), cur.Type().Key(), o)
+		if err != nil {
+			return err
+		}
+
+		if o.merge.keepExisting && cur.MapIndex(key).IsValid() {
+			continue
+		}
+
+		elem, err := valueTo(iter.Value(), cur.Type().Elem(), o)
+		if err != nil {
+			return err
+		}
+
+		cur.SetMapIndex(key, elem)
+	}
+
+	return nil
+}
+
+// appendSlice - append new slice elements to current slice.
+func appendSlice(cur, newSlice reflect.Value, o *options) error {
+	if newSlice.Type() == cur.Type() {
+		cur.Set(reflect.AppendSlice(cur, newSlice))
+		return nil
+	}
+
+	out := cur
+	for i := 0; i < newSlice.Len(); i++ {
+		elem, err := valueTo(newSlice.Index(i), cur.Type().Elem(), o)
+		if err != nil {
+			return err
+		}
+
+		out = reflect.Append(out, elem)
+	}
+
+	cur.Set(out)
+
+	return nil
+}
+
+// valueTo - value of type t: the same value, converted with WithConvert or error.
+func valueTo(v reflect.Value, t reflect.Type, o *options) (reflect.Value, error) {
+	if v.Type() == t {
+		return v, nil
+	}
+
+	if o.convert {
+		return convertValue(v, t)
+	}
+
+	return reflect.Value{}, typeError(ErrWrongFieldValueType, t, v.Type())
+}
@@ -0,0 +1,325 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type mergeAddress struct {
+	City   string
+	Street string
+}
+
+type mergeProfile struct {
+	Name     string
+	Age      int
+	Tags     []string
+	Labels   map[string]string
+	Address  mergeAddress
+	Billing  *mergeAddress
+	Updated  time.Time
+	Password string
+}
+
+type mergePatch struct {
+	Name    *string
+	Age     int
+	Tags    []string
+	Labels  map[string]string
+	Address *mergeAddress
+	Billing mergeAddress
+	Updated time.Time
+	Comment string
+}
+
+func TestSetStructAttrsMerge(t *testing.T) {
+	newName := "new_name"
+	updated := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	patch := mergePatch{
+		Name:    &newName,
+		Tags:    []string{"b"},
+		Labels:  map[string]string{"env": "dev", "region": "eu"},
+		Address: &mergeAddress{City: "new_city"},
+		Billing: mergeAddress{City: "billing_city"},
+		Updated: updated,
+	}
+
+	testCases := []struct {
+		obj         *mergeProfile
+		opts        []Option
+		expected    *mergeProfile
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field absent in current struct",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing()},
+			expected: &mergeProfile{
+				Name:     newName,
+				Tags:     []string{"b"},
+				Labels:   map[string]string{"env": "dev", "region": "eu"},
+				Address:  mergeAddress{City: "new_city"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Overwrite by default",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithSkipZero()},
+			expected: &mergeProfile{
+				Name:     newName,
+				Age:      30,
+				Tags:     []string{"b"},
+				Labels:   map[string]string{"env": "dev", "region": "eu"},
+				Address:  mergeAddress{City: "new_city"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Skip zero values",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithKeepExisting()},
+			expected: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Keep existing values",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithSkipZero(), WithAppendSlices(), WithMergeMaps()},
+			expected: &mergeProfile{
+				Name:     newName,
+				Age:      30,
+				Tags:     []string{"a", "b"},
+				Labels:   map[string]string{"env": "dev", "team": "core", "region": "eu"},
+				Address:  mergeAddress{City: "new_city"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Append slices and merge maps",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithKeepExisting(), WithMergeMaps()},
+			expected: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core", "region": "eu"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Merge maps keeping existing keys",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithSkipZero(), WithDeepMerge()},
+			expected: &mergeProfile{
+				Name:     newName,
+				Age:      30,
+				Tags:     []string{"b"},
+				Labels:   map[string]string{"env": "dev", "region": "eu"},
+				Address:  mergeAddress{City: "new_city", Street: "street"},
+				Billing:  &mergeAddress{City: "billing_city"},
+				Updated:  updated,
+				Password: "password",
+			},
+			testName: "OK. Deep merge nested structs",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithSkipZero(), WithDeepMerge(), WithFields("Name", "Address.City")},
+			expected: &mergeProfile{
+				Name:     newName,
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "new_city", Street: "street"},
+				Password: "password",
+			},
+			testName: "OK. Allow list",
+		},
+		{
+			obj: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"a"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "city", Street: "street"},
+				Password: "password",
+			},
+			opts: []Option{WithIgnoreMissing(), WithSkipZero(), WithoutFields("Name", "Labels", "Billing", "Updated")},
+			expected: &mergeProfile{
+				Name:     "name",
+				Age:      30,
+				Tags:     []string{"b"},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Address:  mergeAddress{City: "new_city"},
+				Password: "password",
+			},
+			testName: "OK. Deny list",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetStructAttrs")
+			t.Description("Check func `SetStructAttrs` with merge policies")
+			t.WithParameters(
+				allure.NewParameter("patch", patch),
+			)
+
+			if err := SetStructAttrs(testCase.obj, patch, testCase.opts...); err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("SetStructAttrs error: %v", err))
+				return
+			}
+
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check SetStructAttrs")
+		})
+	}
+}
+
+func TestSetStructAttrsMergeConvert(t *testing.T) {
+	runner.Run(t, "OK. Maps and slices with different element types", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetStructAttrs")
+		t.Description("Check func `SetStructAttrs` merging containers with `WithConvert` option")
+
+		type Current struct {
+			Counts map[string]int64
+			IDs    []int64
+		}
+
+		type Patch struct {
+			Counts map[string]int
+			IDs    []int
+		}
+
+		cur := &Current{Counts: map[string]int64{"a": 1}, IDs: []int64{1}}
+		patch := Patch{Counts: map[string]int{"b": 2}, IDs: []int{2}}
+
+		err := SetStructAttrs(cur, patch, WithMergeMaps(), WithAppendSlices())
+		t.Assert().ErrorIs(err, ErrWrongFieldValueType)
+
+		err = SetStructAttrs(cur, patch, WithMergeMaps(), WithAppendSlices(), WithConvert())
+		t.Require().NoError(err)
+		t.Assert().Equal(&Current{Counts: map[string]int64{"a": 1, "b": 2}, IDs: []int64{1, 2}}, cur)
+	})
+}
+
+func ExampleWithDeepMerge() {
+	type Address struct {
+		City   string
+		Street string
+	}
+
+	type User struct {
+		Name    string
+		Tags    []string
+		Address Address
+	}
+
+	type Patch struct {
+		Tags    []string
+		Address Address
+		Comment string
+	}
+
+	user := &User{Name: "name", Tags: []string{"a"}, Address: Address{City: "city", Street: "street"}}
+	patch := Patch{Tags: []string{"b"}, Address: Address{City: "new_city"}, Comment: "comment"}
+
+	err := SetStructAttrs(user, patch, WithDeepMerge(), WithSkipZero(), WithAppendSlices(), WithIgnoreMissing())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", *user)
+	// Output: {Name:name Tags:[a b] Address:{City:new_city Street:street}}
+}
@@ -0,0 +1,55 @@
+package attrs
+
+// Option - optional behaviour of attrs funcs.
+type Option func(*options)
+
+// options - collected Option values.
+type options struct {
+	convert      bool                        // convert new value to field type
+	tagKey       string                      // struct tag key for field names
+	merge        mergeOptions                // SetStructAttrs merge policies
+	floatPrec    int                         // precision of float comparison
+	hasFloatPrec bool                        // floats are compared after rounding to floatPrec
+	roundMode    RoundMode                   // RoundStructFloatFields default rounding mode
+	dottedKeys   bool                        // ToMap flattens nested structs to dotted keys
+	unexported   bool                        // Clone deep-copies unexported fields
+	redact       map[string]RedactMode       // Redact modes by field path
+	lookupEnv    func(string) (string, bool) // FromEnv variables lookup
+	csvComma     rune                        // CSV field delimiter
+	separator    string                      // Flatten keys separator
+	notation     IndexNotation               // Flatten slice index notation
+	absTol       float64                     // absolute tolerance of float comparison
+	relTol       float64                     // relative tolerance of float comparison
+	ulpTol       uint64                      // ULP tolerance of float comparison
+	ignore       map[string]bool             // Equal ignored field paths
+	nilEmpty     bool                        // Equal treats nil and empty slices and maps as equal
+	unordered    bool                        // Equal compares slices as multisets
+	allDiffs     bool                        // Equal reports all differences
+}
+
+// defaultOptions - options without Option values, must not be changed.
+var defaultOptions = &options{}
+
+// newOptions - apply opts to default options.
+func newOptions(opts []Option) *options {
+	if len(opts) == 0 {
+		return defaultOptions
+	}
+
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithConvert - convert new value to field type instead of strict types check.
+// Supported: numbers with overflow check, strings to numbers, bool, time.Time, time.Duration,
+// named types with the same kind and encoding.TextUnmarshaler.
+func WithConvert() Option {
+	return func(o *options) {
+		o.convert = true
+	}
+}
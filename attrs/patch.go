@@ -0,0 +1,844 @@
+package attrs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonTagKey - default tag key of JSON Patch and Merge Patch field names.
+const jsonTagKey = "json"
+
+// JSON Patch operations, RFC 6902.
+const (
+	patchAdd     = "add"
+	patchRemove  = "remove"
+	patchReplace = "replace"
+	patchMove    = "move"
+	patchCopy    = "copy"
+	patchTest    = "test"
+)
+
+// jsonPatchOp - operation of JSON Patch document.
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"` // empty if not set, `null` for null
+}
+
+// ApplyJSONPatch - apply RFC 6902 JSON Patch document to struct: add, remove, replace, move, copy and test.
+// JSON pointers like `/items/0/price` are mapped to fields by json tags, slice indexes and map keys, `-` appends
+// to slice. Values are set like SetAttr with WithConvert, removed fields are set to zero values.
+// Struct is patched in place: ptrs, slices and maps of not touched paths are kept. Patch is atomic:
+// on error all applied changes are undone.
+// 'obj': ptr struct.
+// 'patch': JSON Patch document, array of operations.
+// 'opts': optional, WithTagKey for other tag names than json.
+func ApplyJSONPatch(obj interface{}, patch []byte, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr || objValue.IsNil() {
+		return objError(opApplyJSONPatch, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opApplyJSONPatch, ErrNotStruct, objValue)
+	}
+
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return &AttrError{Op: opApplyJSONPatch, Err: fmt.Errorf("%w: %v", ErrInvalidPatch, err)}
+	}
+
+	p := newPatcher(opts)
+	for i := range ops {
+		if err := p.apply(objValue.Elem(), &ops[i]); err != nil {
+			p.rollback()
+			return opError(opApplyJSONPatch, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyMergePatch - apply RFC 7396 JSON Merge Patch document to struct. Object members are merged into
+// nested structs and maps, nulls set fields to zero values and delete map keys, other values replace fields.
+// Fields are found by json tags, values are set like SetAttr with WithConvert.
+// Struct is patched in place: ptrs, slices and maps of not touched paths are kept. Patch is atomic:
+// on error all applied changes are undone.
+// 'obj': ptr struct.
+// 'patch': JSON Merge Patch document, object.
+// 'opts': optional, WithTagKey for other tag names than json.
+func ApplyMergePatch(obj interface{}, patch []byte, opts ...Option) error {
+	objValue := reflect.ValueOf(obj)
+	// struct ptr check
+	if objValue.Kind() != reflect.Ptr || objValue.IsNil() {
+		return objError(opApplyMergePatch, ErrNotPointerStruct, objValue)
+	}
+	// is struct check
+	if objValue.Elem().Kind() != reflect.Struct {
+		return objError(opApplyMergePatch, ErrNotStruct, objValue)
+	}
+
+	value, err := decodeJSON(patch)
+	if err != nil {
+		return opError(opApplyMergePatch, err)
+	}
+	// patch of struct is object
+	members, ok := value.(map[string]interface{})
+	if !ok {
+		return &AttrError{Op: opApplyMergePatch, Err: fmt.Errorf("%w: not an object", ErrInvalidPatch)}
+	}
+
+	p := newPatcher(opts)
+	if err := p.fill(objValue.Elem(), members, true); err != nil {
+		p.rollback()
+		return opError(opApplyMergePatch, err)
+	}
+
+	return nil
+}
+
+// newValueFunc - new value of type for add, replace, move, copy and test.
+type newValueFunc func(t reflect.Type) (reflect.Value, error)
+
+// patcher - applies JSON Patch operations and merge patches to struct values.
+type patcher struct {
+	o    *options // json tag key, conversion like SetAttr with WithConvert
+	undo []func() // restores of changed values, in change order
+}
+
+// newPatcher - patcher with json tag key by default.
+func newPatcher(opts []Option) *patcher {
+	o := *newOptions(opts)
+	o.convert = true
+
+	if o.tagKey == "" {
+		o.tagKey = jsonTagKey
+	}
+
+	return &patcher{o: &o}
+}
+
+// save - remember settable value before it is changed.
+func (p *patcher) save(v reflect.Value) {
+	old := reflect.New(v.Type()).Elem()
+	old.Set(v)
+
+	p.undo = append(p.undo, func() { v.Set(old) })
+}
+
+// saveKey - remember map entry before it is changed, absent entry is deleted on rollback.
+func (p *patcher) saveKey(m, key reflect.Value) {
+	old := m.MapIndex(key)
+
+	p.undo = append(p.undo, func() { m.SetMapIndex(key, old) })
+}
+
+// rollback - undo all saved changes in reverse order.
+func (p *patcher) rollback() {
+	for i := len(p.undo) - 1; i >= 0; i-- {
+		p.undo[i]()
+	}
+
+	p.undo = nil
+}
+
+// apply - apply JSON Patch operation to root struct value. Errors have pointer of op path or from.
+func (p *patcher) apply(root reflect.Value, op *jsonPatchOp) error {
+	newValue, err := p.opValue(root, op)
+	if err != nil {
+		return err
+	}
+
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return pointerError(err, op.Path)
+	}
+
+	switch op.Op {
+	case patchRemove:
+		err = p.remove(root, path)
+	case patchTest:
+		err = p.test(root, path, newValue)
+	default:
+		err = p.set(root, path, newValue, op.Op != patchReplace)
+	}
+
+	if err != nil {
+		return pointerError(err, op.Path)
+	}
+
+	return nil
+}
+
+// opValue - func of new value of operation: decoded value of add, replace and test, value from pointer of
+// move and copy. Value of move is removed from its pointer.
+func (p *patcher) opValue(root reflect.Value, op *jsonPatchOp) (newValueFunc, error) {
+	switch op.Op {
+	case patchAdd, patchReplace, patchTest:
+		// value check
+		if len(op.Value) == 0 {
+			return nil, pointerError(fmt.Errorf("%w: %s without value", ErrInvalidPatch, op.Op), op.Path)
+		}
+
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, pointerError(err, op.Path)
+		}
+
+		return p.jsonValue(value), nil
+	case patchMove, patchCopy:
+		// value is moved into itself check
+		if op.Op == patchMove && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, pointerError(fmt.Errorf("%w: move to child of %q", ErrInvalidPath, op.From), op.Path)
+		}
+
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, pointerError(err, op.From)
+		}
+
+		cur, err := p.get(root, from)
+		if err != nil {
+			return nil, pointerError(err, op.From)
+		}
+		// value is not shared with source
+		moved := (&cloner{o: defaultOptions}).clone(cur)
+
+		if op.Op == patchMove {
+			if err := p.remove(root, from); err != nil {
+				return nil, pointerError(err, op.From)
+			}
+		}
+
+		return func(t reflect.Type) (reflect.Value, error) {
+			return valueTo(moved, t, p.o)
+		}, nil
+	case patchRemove:
+		return nil, nil
+	default:
+		return nil, pointerError(fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op), op.Path)
+	}
+}
+
+// test - compare value by pointer tokens with expected value of the same type.
+func (p *patcher) test(root reflect.Value, path []string, expected newValueFunc) error {
+	cur, err := p.get(root, path)
+	if err != nil {
+		return err
+	}
+
+	value, err := expected(cur.Type())
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(cur.Interface(), value.Interface()) {
+		return fmt.Errorf("%w: value is %v", ErrPatchTestFailed, cur.Interface())
+	}
+
+	return nil
+}
+
+// get - value by pointer tokens.
+func (p *patcher) get(root reflect.Value, path []string) (reflect.Value, error) {
+	if len(path) == 0 {
+		return root, nil
+	}
+
+	var out reflect.Value
+
+	err := p.at(root, path, false, func(c reflect.Value, token string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			field, err := p.field(c, token, false)
+			out = field
+
+			return err
+		case reflect.Slice, reflect.Array:
+			i, err := p.index(c, token, false)
+			if err != nil {
+				return err
+			}
+
+			out = c.Index(i)
+		case reflect.Map:
+			key, err := p.key(c, token)
+			if err != nil {
+				return err
+			}
+
+			if out = c.MapIndex(key); !out.IsValid() {
+				return ErrKeyNotInMap
+			}
+		default:
+			return ErrNotIndexable
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+// set - add or replace value by pointer tokens. Add inserts into slices and adds map keys,
+// replace needs existing slice element or map key.
+func (p *patcher) set(root reflect.Value, path []string, newValue newValueFunc, add bool) error {
+	// whole struct
+	if len(path) == 0 {
+		value, err := newValue(root.Type())
+		if err != nil {
+			return err
+		}
+
+		p.save(root)
+		root.Set(value)
+
+		return nil
+	}
+
+	return p.at(root, path, true, func(c reflect.Value, token string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			field, err := p.field(c, token, true)
+			if err != nil {
+				return err
+			}
+
+			value, err := newValue(field.Type())
+			if err != nil {
+				return err
+			}
+
+			p.save(field)
+			field.Set(value)
+		case reflect.Slice, reflect.Array:
+			i, err := p.index(c, token, add && c.Kind() == reflect.Slice)
+			if err != nil {
+				return err
+			}
+
+			value, err := newValue(c.Type().Elem())
+			if err != nil {
+				return err
+			}
+			// replace or add to array
+			if !add || c.Kind() == reflect.Array {
+				p.save(c.Index(i))
+				c.Index(i).Set(value)
+
+				return nil
+			}
+			// insert before i
+			out := reflect.MakeSlice(c.Type(), 0, c.Len()+1)
+			out = reflect.AppendSlice(out, c.Slice(0, i))
+			out = reflect.Append(out, value)
+			out = reflect.AppendSlice(out, c.Slice(i, c.Len()))
+			p.save(c)
+			c.Set(out)
+		case reflect.Map:
+			key, err := p.key(c, token)
+			if err != nil {
+				return err
+			}
+			// replace needs existing key
+			if !add && !c.MapIndex(key).IsValid() {
+				return ErrKeyNotInMap
+			}
+
+			value, err := newValue(c.Type().Elem())
+			if err != nil {
+				return err
+			}
+
+			if c.IsNil() {
+				p.save(c)
+				c.Set(reflect.MakeMap(c.Type()))
+			}
+
+			p.saveKey(c, key)
+			c.SetMapIndex(key, value)
+		default:
+			return ErrNotIndexable
+		}
+
+		return nil
+	})
+}
+
+// remove - remove value by pointer tokens: struct fields and array elements are set to zero values,
+// slice elements and map keys are deleted.
+func (p *patcher) remove(root reflect.Value, path []string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("%w: remove of whole struct", ErrInvalidPath)
+	}
+
+	return p.at(root, path, false, func(c reflect.Value, token string) error {
+		switch c.Kind() {
+		case reflect.Struct:
+			field, err := p.field(c, token, false)
+			if err != nil {
+				return err
+			}
+
+			p.save(field)
+			field.Set(reflect.Zero(field.Type()))
+		case reflect.Slice, reflect.Array:
+			i, err := p.index(c, token, false)
+			if err != nil {
+				return err
+			}
+
+			if c.Kind() == reflect.Array {
+				p.save(c.Index(i))
+				c.Index(i).Set(reflect.Zero(c.Type().Elem()))
+
+				return nil
+			}
+
+			out := reflect.MakeSlice(c.Type(), 0, c.Len()-1)
+			out = reflect.AppendSlice(out, c.Slice(0, i))
+			out = reflect.AppendSlice(out, c.Slice(i+1, c.Len()))
+			p.save(c)
+			c.Set(out)
+		case reflect.Map:
+			key, err := p.key(c, token)
+			if err != nil {
+				return err
+			}
+
+			if !c.MapIndex(key).IsValid() {
+				return ErrKeyNotInMap
+			}
+
+			p.saveKey(c, key)
+			c.SetMapIndex(key, reflect.Value{})
+		default:
+			return ErrNotIndexable
+		}
+
+		return nil
+	})
+}
+
+// at - call fn with container of the last pointer token and the token. Nil ptrs are allocated if 'alloc'.
+// Map elements and interface values are not addressable: fn is called with a copy which is put back.
+// Changes are saved for rollback.
+func (p *patcher) at(v reflect.Value, path []string, alloc bool, fn func(c reflect.Value, token string) error) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		// nil ptr check
+		if v.IsNil() {
+			if !alloc {
+				return ErrNilPointer
+			}
+			p.save(v)
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return p.at(v.Elem(), path, alloc, fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return ErrNilPointer
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+
+		if err := p.at(elem, path, alloc, fn); err != nil {
+			return err
+		}
+
+		p.save(v)
+		v.Set(elem)
+
+		return nil
+	default:
+	}
+	// container of the last token
+	if len(path) == 1 {
+		return fn(v, path[0])
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, err := p.field(v, path[0], alloc)
+		if err != nil {
+			return err
+		}
+
+		return p.at(field, path[1:], alloc, fn)
+	case reflect.Slice, reflect.Array:
+		i, err := p.index(v, path[0], false)
+		if err != nil {
+			return err
+		}
+
+		return p.at(v.Index(i), path[1:], alloc, fn)
+	case reflect.Map:
+		key, err := p.key(v, path[0])
+		if err != nil {
+			return err
+		}
+
+		cur := v.MapIndex(key)
+		if !cur.IsValid() {
+			return ErrKeyNotInMap
+		}
+
+		elem := reflect.New(cur.Type()).Elem()
+		elem.Set(cur)
+
+		if err := p.at(elem, path[1:], alloc, fn); err != nil {
+			return err
+		}
+
+		p.saveKey(v, key)
+		v.SetMapIndex(key, elem)
+
+		return nil
+	default:
+		return ErrNotIndexable
+	}
+}
+
+// field - exported struct field by tag name. Embedded nil ptrs are allocated if 'alloc'.
+func (p *patcher) field(v reflect.Value, name string, alloc bool) (reflect.Value, error) {
+	f, ok := lookupField(v.Type(), name, p.o)
+	// is field in struct
+	if !ok {
+		return reflect.Value{}, ErrFieldNotInStruct
+	}
+	// is field exported
+	if !f.exported {
+		return reflect.Value{}, ErrUnexportedField
+	}
+
+	if alloc && f.viaPtr {
+		p.saveEmbedded(v, f)
+	}
+
+	return fieldByInfo(v, f, alloc)
+}
+
+// saveEmbedded - save the first embedded nil ptr on the path of field, it is allocated by fieldByInfo.
+func (p *patcher) saveEmbedded(v reflect.Value, f *fieldInfo) {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if v.CanSet() {
+					p.save(v)
+				}
+
+				return
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+}
+
+// index - slice index of pointer token, `-` and len are allowed only for insert.
+func (p *patcher) index(v reflect.Value, token string, insert bool) (int, error) {
+	if insert && token == "-" {
+		return v.Len(), nil
+	}
+	// leading zeros are not allowed by RFC 6901
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || len(token) > 1 && token[0] == '0' {
+		return 0, fmt.Errorf("%w: index %q", ErrInvalidPath, token)
+	}
+
+	if i > v.Len() || i == v.Len() && !insert {
+		return 0, ErrIndexOutOfRange
+	}
+
+	return i, nil
+}
+
+// key - map key of pointer token.
+func (p *patcher) key(v reflect.Value, token string) (reflect.Value, error) {
+	return convertValue(reflect.ValueOf(token), v.Type().Key())
+}
+
+// jsonValue - func of new value of type from decoded JSON value.
+func (p *patcher) jsonValue(value interface{}) newValueFunc {
+	return func(t reflect.Type) (reflect.Value, error) {
+		out := reflect.New(t).Elem()
+		if err := p.assign(out, value); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return out, nil
+	}
+}
+
+// assign - set settable value from decoded JSON value: objects to structs and maps, arrays to slices and arrays,
+// other values like SetAttr with WithConvert, null to zero value.
+func (p *patcher) assign(v reflect.Value, value interface{}) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		plain := reflect.ValueOf(plainJSON(value))
+		// interface implementation check
+		if !plain.Type().AssignableTo(v.Type()) {
+			return typeError(ErrWrongFieldValueType, v.Type(), plain.Type())
+		}
+
+		v.Set(plain)
+	case reflect.Ptr:
+		out := reflect.New(v.Type().Elem())
+		if err := p.assign(out.Elem(), value); err != nil {
+			return err
+		}
+
+		v.Set(out)
+	case reflect.Struct, reflect.Map:
+		members, ok := value.(map[string]interface{})
+		// struct parsed from string like time.Time
+		if !ok {
+			return p.assignScalar(v, value)
+		}
+
+		out := reflect.New(v.Type()).Elem()
+		if err := p.fill(out, members, false); err != nil {
+			return err
+		}
+
+		v.Set(out)
+	case reflect.Slice, reflect.Array:
+		elems, ok := value.([]interface{})
+		if !ok {
+			return p.assignScalar(v, value)
+		}
+
+		out := reflect.New(v.Type()).Elem()
+		if v.Kind() == reflect.Slice {
+			out.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		} else if len(elems) > v.Len() {
+			return ErrIndexOutOfRange
+		}
+
+		for i, elem := range elems {
+			if err := p.assign(out.Index(i), elem); err != nil {
+				return pointerError(err, "/"+strconv.Itoa(i))
+			}
+		}
+
+		v.Set(out)
+	default:
+		return p.assignScalar(v, value)
+	}
+
+	return nil
+}
+
+// assignScalar - set JSON string, number or bool like SetAttr with WithConvert.
+func (p *patcher) assignScalar(v reflect.Value, value interface{}) error {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return typeError(ErrWrongFieldValueType, v.Type(), reflect.TypeOf(value))
+	default:
+		return assign(v, reflect.ValueOf(value), p.o)
+	}
+}
+
+// fill - set fields of struct or entries of map from JSON object members. Merge patch merges objects
+// into nested structs and maps and deletes map keys by nulls, else values replace fields.
+func (p *patcher) fill(v reflect.Value, members map[string]interface{}, merge bool) error {
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	if v.Kind() == reflect.Map && v.IsNil() {
+		p.save(v)
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(members)))
+	}
+
+	for _, name := range names {
+		if err := p.fillMember(v, name, members[name], merge); err != nil {
+			return pointerError(err, "/"+escapePointer(name))
+		}
+	}
+
+	return nil
+}
+
+// fillMember - set struct field or map entry from JSON object member.
+func (p *patcher) fillMember(v reflect.Value, name string, value interface{}, merge bool) error {
+	if v.Kind() == reflect.Struct {
+		field, err := p.field(v, name, true)
+		if err != nil {
+			return err
+		}
+
+		if merge {
+			return p.merge(field, value)
+		}
+
+		return p.assign(field, value)
+	}
+
+	key, err := p.key(v, name)
+	if err != nil {
+		return err
+	}
+	// null deletes map key
+	if merge && value == nil {
+		p.saveKey(v, key)
+		v.SetMapIndex(key, reflect.Value{})
+
+		return nil
+	}
+
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if cur := v.MapIndex(key); merge && cur.IsValid() {
+		elem.Set(cur)
+	}
+
+	if merge {
+		err = p.merge(elem, value)
+	} else {
+		err = p.assign(elem, value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.saveKey(v, key)
+	v.SetMapIndex(key, elem)
+
+	return nil
+}
+
+// merge - merge JSON value into settable value: objects are merged into structs and maps, other values replace.
+// Changes are saved for rollback.
+func (p *patcher) merge(v reflect.Value, value interface{}) error {
+	members, ok := value.(map[string]interface{})
+	if !ok {
+		return p.replace(v, value)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			p.save(v)
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return p.merge(v.Elem(), value)
+	case reflect.Interface:
+		// generic object is merged into generic map
+		if v.IsNil() || v.Elem().Kind() != reflect.Map {
+			return p.replace(v, value)
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+
+		if err := p.merge(elem, value); err != nil {
+			return err
+		}
+
+		p.save(v)
+		v.Set(elem)
+
+		return nil
+	case reflect.Struct, reflect.Map:
+		return p.fill(v, members, true)
+	default:
+		return p.replace(v, value)
+	}
+}
+
+// replace - assign JSON value to settable value saved for rollback.
+func (p *patcher) replace(v reflect.Value, value interface{}) error {
+	p.save(v)
+	return p.assign(v, value)
+}
+
+// decodeJSON - JSON value with numbers as json.Number, so big integers are not rounded.
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return value, nil
+}
+
+// plainJSON - JSON value for interface fields like encoding/json: numbers as float64.
+func plainJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return value.String()
+		}
+
+		return f
+	case []interface{}:
+		for i, elem := range value {
+			value[i] = plainJSON(elem)
+		}
+	case map[string]interface{}:
+		for key, elem := range value {
+			value[key] = plainJSON(elem)
+		}
+	}
+
+	return value
+}
+
+// parsePointer - tokens of RFC 6901 JSON pointer, `~1` is `/` and `~0` is `~`.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: pointer %q", ErrInvalidPath, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// escapePointer - JSON pointer token of name.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// pointerError - prepend JSON pointer prefix to AttrError path.
+func pointerError(err error, prefix string) error {
+	var attrErr *AttrError
+	if !errors.As(err, &attrErr) {
+		return &AttrError{Path: prefix, Err: err}
+	}
+
+	attrErr.Path = prefix + attrErr.Path
+
+	return attrErr
+}
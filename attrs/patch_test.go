@@ -0,0 +1,476 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type patchCustomer struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type patchItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+type patchOrder struct {
+	ID       int64                  `json:"id"`
+	Customer *patchCustomer         `json:"customer"`
+	Items    []patchItem            `json:"items"`
+	Labels   map[string]string      `json:"labels"`
+	Stock    map[int]patchItem      `json:"stock"`
+	Extra    map[string]interface{} `json:"extra"`
+	Created  time.Time              `json:"created"`
+	TTL      time.Duration          `json:"ttl"`
+	Internal string                 `json:"-"`
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	testCases := []struct {
+		obj         *patchOrder
+		patch       string
+		expected    *patchOrder
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `[
+				{"op": "replace", "path": "/id", "value": 9007199254740993},
+				{"op": "replace", "path": "/customer/name", "value": "jane"},
+				{"op": "add", "path": "/items/1", "value": {"sku": "c", "price": 3}},
+				{"op": "add", "path": "/items/-", "value": {"sku": "d"}},
+				{"op": "remove", "path": "/items/0"},
+				{"op": "add", "path": "/labels/team", "value": "core"},
+				{"op": "remove", "path": "/labels/a~1b"},
+				{"op": "replace", "path": "/stock/1/price", "value": "4.5"},
+				{"op": "add", "path": "/extra/nested/n", "value": 1},
+				{"op": "add", "path": "/created", "value": "2024-01-02T00:00:00Z"},
+				{"op": "add", "path": "/ttl", "value": "5s"}
+			]`,
+			expected: &patchOrder{
+				ID:       9007199254740993,
+				Customer: &patchCustomer{Name: "jane", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "c", Price: 3}, {SKU: "b", Price: 2.5}, {SKU: "d"}},
+				Labels:   map[string]string{"env": "prod", "team": "core"},
+				Stock:    map[int]patchItem{1: {SKU: "a", Price: 4.5}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v", "n": float64(1)}},
+				Created:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				TTL:      5 * time.Second,
+			},
+			testName: "OK. Add, remove and replace",
+		},
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `[
+				{"op": "test", "path": "/customer/name", "value": "john"},
+				{"op": "move", "path": "/labels/old", "from": "/labels/env"},
+				{"op": "copy", "path": "/items/0", "from": "/items/1"},
+				{"op": "copy", "path": "/stock/2", "from": "/items/0"},
+				{"op": "remove", "path": "/customer"},
+				{"op": "test", "path": "/items/1", "value": {"sku": "a", "price": 1.5}}
+			]`,
+			expected: &patchOrder{
+				ID:     1,
+				Items:  []patchItem{{SKU: "b", Price: 2.5}, {SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels: map[string]string{"old": "prod", "a/b": "slash"},
+				Stock:  map[int]patchItem{1: {SKU: "a"}, 2: {SKU: "b", Price: 2.5}},
+				Extra:  map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			testName: "OK. Move, copy and test",
+		},
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch:    `[{"op": "replace", "path": "", "value": {"id": 2, "items": []}}]`,
+			expected: &patchOrder{ID: 2, Items: []patchItem{}},
+			testName: "OK. Replace whole struct",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "add", "path": "/id", "value": 2}, {"op": "test", "path": "/id", "value": 1}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrPatchTestFailed,
+			testName:    "ERR. Test failed, struct not changed",
+		},
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `[
+				{"op": "replace", "path": "/customer/name", "value": "jane"},
+				{"op": "add", "path": "/labels/team", "value": "core"},
+				{"op": "remove", "path": "/items/0"},
+				{"op": "add", "path": "/extra/nested/n", "value": 1},
+				{"op": "replace", "path": "/items/0/price", "value": "p"}
+			]`,
+			expected: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Conversion after changes, struct restored",
+		},
+		{
+			obj:         &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}}},
+			patch:       `[{"op": "replace", "path": "/items/2/price", "value": 1}]`,
+			expected:    &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}}},
+			expectedErr: ErrIndexOutOfRange,
+			testName:    "ERR. Index out of range",
+		},
+		{
+			obj:         &patchOrder{Labels: map[string]string{"env": "prod"}},
+			patch:       `[{"op": "replace", "path": "/labels/team", "value": "core"}]`,
+			expected:    &patchOrder{Labels: map[string]string{"env": "prod"}},
+			expectedErr: ErrKeyNotInMap,
+			testName:    "ERR. Replace missing key",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "add", "path": "/phone", "value": "1"}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "add", "path": "/Internal", "value": "1"}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Skipped field",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "replace", "path": "/id", "value": "id"}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Conversion",
+		},
+		{
+			obj:         &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}}},
+			patch:       `[{"op": "add", "path": "/items/01", "value": {}}]`,
+			expected:    &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}}},
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Leading zero index",
+		},
+		{
+			obj:         &patchOrder{Customer: &patchCustomer{Name: "john"}},
+			patch:       `[{"op": "move", "path": "/customer/name", "from": "/customer"}]`,
+			expected:    &patchOrder{Customer: &patchCustomer{Name: "john"}},
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Move to child",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "replace", "path": "/id"}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrInvalidPatch,
+			testName:    "ERR. No value",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[{"op": "merge", "path": "/id", "value": 1}]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrInvalidPatch,
+			testName:    "ERR. Unknown op",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `{"op": "add"}`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrInvalidPatch,
+			testName:    "ERR. Not an array",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("ApplyJSONPatch")
+			t.Description("Check func `ApplyJSONPatch`")
+			t.WithParameters(allure.NewParameter("patch", testCase.patch))
+
+			err := ApplyJSONPatch(testCase.obj, []byte(testCase.patch))
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("ApplyJSONPatch error: %v", err))
+				t.Assert().Equal(testCase.expected, testCase.obj, "Check struct not changed")
+
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check ApplyJSONPatch")
+		})
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	runner.Run(t, "ERR. Path in error", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ApplyJSONPatch")
+		t.Description("Check func `ApplyJSONPatch` error path")
+
+		order := patchOrder{Customer: &patchCustomer{Name: "john"}, Items: []patchItem{{SKU: "a", Price: 1.5}}}
+
+		err := ApplyJSONPatch(&order, []byte(`[{"op": "add", "path": "/items/0", "value": {"sku": "a", "price": "p"}}]`))
+		var attrErr *AttrError
+		t.Require().ErrorAs(err, &attrErr)
+		t.Assert().ErrorIs(err, ErrConversion)
+		t.Assert().Equal("/items/0/price", attrErr.Path)
+
+		err = ApplyJSONPatch(&order, []byte(`[{"op": "copy", "path": "/items/0", "from": "/customer/phone"}]`))
+		t.Assert().EqualError(err, "ApplyJSONPatch /customer/phone: field not in struct")
+	})
+
+	runner.Run(t, "ERR. Invalid args", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ApplyJSONPatch")
+		t.Description("Check func `ApplyJSONPatch` with invalid args")
+
+		t.Assert().ErrorIs(ApplyJSONPatch(patchOrder{}, []byte(`[]`)), ErrNotPointerStruct)
+		t.Assert().ErrorIs(ApplyJSONPatch(new(int), []byte(`[]`)), ErrNotStruct)
+	})
+}
+
+func TestApplyPatchReferences(t *testing.T) {
+	runner.Run(t, "OK. References of not touched paths are kept", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ApplyJSONPatch")
+		t.Description("Check funcs `ApplyJSONPatch` and `ApplyMergePatch` patch struct in place")
+
+		order := patchOrder{
+			Customer: &patchCustomer{Name: "john"},
+			Items:    []patchItem{{SKU: "a", Price: 1.5}},
+			Labels:   map[string]string{"env": "prod"},
+		}
+		customer, items, labels := order.Customer, &order.Items[0], order.Labels
+
+		t.Require().NoError(ApplyJSONPatch(&order, []byte(`[{"op": "replace", "path": "/customer/name", "value": "jane"}]`)))
+		t.Require().NoError(ApplyMergePatch(&order, []byte(`{"id": 2, "labels": {"team": "core"}}`)))
+		t.Assert().Same(customer, order.Customer)
+		t.Assert().Equal("jane", customer.Name)
+		t.Assert().Same(items, &order.Items[0])
+		t.Assert().Equal("core", labels["team"])
+	})
+
+	runner.Run(t, "ERR. Changes via references are undone", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("ApplyJSONPatch")
+		t.Description("Check funcs `ApplyJSONPatch` and `ApplyMergePatch` undo changes of shared values on error")
+
+		order := patchOrder{ID: 1, Customer: &patchCustomer{Name: "john"}, Labels: map[string]string{"env": "prod"}}
+		customer, labels := order.Customer, order.Labels
+
+		err := ApplyJSONPatch(&order, []byte(`[
+			{"op": "replace", "path": "/customer/name", "value": "jane"},
+			{"op": "remove", "path": "/labels/env"},
+			{"op": "test", "path": "/id", "value": 2}
+		]`))
+		t.Assert().ErrorIs(err, ErrPatchTestFailed)
+
+		err = ApplyMergePatch(&order, []byte(`{"customer": {"name": "jane"}, "labels": {"env": null}, "ttl": "p"}`))
+		t.Assert().ErrorIs(err, ErrConversion)
+		t.Assert().Same(customer, order.Customer)
+		t.Assert().Equal(patchOrder{
+			ID:       1,
+			Customer: &patchCustomer{Name: "john"},
+			Labels:   map[string]string{"env": "prod"},
+		}, order)
+		t.Assert().Equal("prod", labels["env"])
+	})
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	testCases := []struct {
+		obj         *patchOrder
+		patch       string
+		expected    *patchOrder
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `{
+				"id": 2,
+				"customer": {"email": null},
+				"items": [{"sku": "c"}],
+				"labels": {"env": null, "team": "core"},
+				"stock": {"1": {"price": 3}, "2": {"sku": "b"}},
+				"extra": {"nested": {"k": null, "n": 1}},
+				"ttl": "1m"
+			}`,
+			expected: &patchOrder{
+				ID:       2,
+				Customer: &patchCustomer{Name: "john"},
+				Items:    []patchItem{{SKU: "c"}},
+				Labels:   map[string]string{"a/b": "slash", "team": "core"},
+				Stock:    map[int]patchItem{1: {SKU: "a", Price: 3}, 2: {SKU: "b"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"n": float64(1)}},
+				TTL:      time.Minute,
+			},
+			testName: "OK. Merge",
+		},
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `{"customer": null, "labels": null}`,
+			expected: &patchOrder{
+				ID:    1,
+				Items: []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Stock: map[int]patchItem{1: {SKU: "a"}},
+				Extra: map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			testName: "OK. Null fields",
+		},
+		{
+			obj: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			patch: `{"customer": {"name": "jane"}, "labels": {"env": null}, "stock": {"1": {"price": "p"}}}`,
+			expected: &patchOrder{
+				ID:       1,
+				Customer: &patchCustomer{Name: "john", Email: "john@example.com"},
+				Items:    []patchItem{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 2.5}},
+				Labels:   map[string]string{"env": "prod", "a/b": "slash"},
+				Stock:    map[int]patchItem{1: {SKU: "a"}},
+				Extra:    map[string]interface{}{"nested": map[string]interface{}{"k": "v"}},
+			},
+			expectedErr: ErrConversion,
+			testName:    "ERR. Conversion after changes, struct restored",
+		},
+		{
+			obj:         &patchOrder{Customer: &patchCustomer{Name: "john"}},
+			patch:       `{"customer": {"phone": "1"}}`,
+			expected:    &patchOrder{Customer: &patchCustomer{Name: "john"}},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			obj:         &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}}},
+			patch:       `{"items": {"sku": "c"}}`,
+			expected:    &patchOrder{Items: []patchItem{{SKU: "a", Price: 1.5}}},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Object to slice",
+		},
+		{
+			obj:         &patchOrder{ID: 1},
+			patch:       `[]`,
+			expected:    &patchOrder{ID: 1},
+			expectedErr: ErrInvalidPatch,
+			testName:    "ERR. Not an object",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("ApplyMergePatch")
+			t.Description("Check func `ApplyMergePatch`")
+			t.WithParameters(allure.NewParameter("patch", testCase.patch))
+
+			err := ApplyMergePatch(testCase.obj, []byte(testCase.patch))
+			if testCase.expectedErr != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("ApplyMergePatch error: %v", err))
+				t.Assert().Equal(testCase.expected, testCase.obj, "Check struct not changed")
+
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check ApplyMergePatch")
+		})
+	}
+}
+
+func ExampleApplyJSONPatch() {
+	type Item struct {
+		SKU   string  `json:"sku"`
+		Price float64 `json:"price"`
+	}
+
+	type Order struct {
+		ID    int    `json:"id"`
+		Items []Item `json:"items"`
+	}
+
+	order := Order{ID: 1, Items: []Item{{SKU: "a", Price: 1.5}}}
+
+	patch := `[
+		{"op": "test", "path": "/id", "value": 1},
+		{"op": "replace", "path": "/items/0/price", "value": 2},
+		{"op": "add", "path": "/items/-", "value": {"sku": "b", "price": 3}}
+	]`
+	if err := ApplyJSONPatch(&order, []byte(patch)); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ApplyMergePatch(&order, []byte(`{"id": 2}`)); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", order)
+	// Output: {ID:2 Items:[{SKU:a Price:2} {SKU:b Price:3}]}
+}
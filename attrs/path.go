@@ -0,0 +1,400 @@
+package attrs
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// segment - one step of a field path: struct field name, slice/array index or map key.
+type segment struct {
+	name   string // struct field name, empty for bracket segments
+	key    string // index or map key of bracket segment
+	quoted bool   // map key was passed in quotes
+}
+
+// String - segment as it is written in path.
+func (s segment) String() string {
+	if s.name != "" {
+		return s.name
+	}
+
+	if s.quoted {
+		return "[" + strconv.Quote(s.key) + "]"
+	}
+
+	return "[" + s.key + "]"
+}
+
+// joinPath - build path string from segments.
+func joinPath(segs []segment) string {
+	var b strings.Builder
+
+	for i, seg := range segs {
+		if i > 0 && seg.name != "" {
+			b.WriteByte('.')
+		}
+
+		b.WriteString(seg.String())
+	}
+
+	return b.String()
+}
+
+// pathError - AttrError with the path up to the failed segment.
+func pathError(segs []segment, i int, err error) error {
+	var attrErr *AttrError
+	if !errors.As(err, &attrErr) {
+		attrErr = &AttrError{Err: err}
+	}
+
+	attrErr.Path = joinPath(segs[:i+1])
+
+	return attrErr
+}
+
+// parsePath - split path like `Customer.Address.City`, `Items[2].Price` or `Meta["region"]` into segments.
+func parsePath(path string) ([]segment, error) {
+	var (
+		segs []segment
+		i    int
+	)
+
+	for {
+		// field name
+		j := i
+		for j < len(path) && path[j] != '.' && path[j] != '[' {
+			j++
+		}
+
+		if j == i {
+			return nil, &AttrError{Path: path, Err: ErrInvalidPath}
+		}
+
+		segs = append(segs, segment{name: path[i:j]})
+		i = j
+		// index and key segments
+		for i < len(path) && path[i] == '[' {
+			seg, n, err := parseBracket(path[i:])
+			if err != nil {
+				return nil, &AttrError{Path: path, Err: err}
+			}
+
+			segs = append(segs, seg)
+			i += n
+		}
+
+		if i == len(path) {
+			return segs, nil
+		}
+		// next field name must follow the dot
+		if path[i] != '.' {
+			return nil, &AttrError{Path: path, Err: ErrInvalidPath}
+		}
+		i++
+	}
+}
+
+// parseBracket - parse `[2]` or `["key"]` at the beginning of s. Returns segment and its length.
+func parseBracket(s string) (segment, int, error) {
+	// quoted key
+	if len(s) > 1 && s[1] == '"' {
+		k := 2
+		for k < len(s) && s[k] != '"' {
+			if s[k] == '\\' {
+				k++
+			}
+			k++
+		}
+
+		if k+1 >= len(s) || s[k+1] != ']' {
+			return segment{}, 0, ErrInvalidPath
+		}
+
+		key, err := strconv.Unquote(s[1 : k+1])
+		if err != nil {
+			return segment{}, 0, ErrInvalidPath
+		}
+
+		return segment{key: key, quoted: true}, k + 2, nil
+	}
+
+	k := strings.IndexByte(s, ']')
+	if k <= 1 {
+		return segment{}, 0, ErrInvalidPath
+	}
+
+	return segment{key: s[1:k]}, k + 1, nil
+}
+
+// isFieldName - path is a single field name without dots and brackets.
+func isFieldName(path string) bool {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '.' || path[i] == '[' {
+			return false
+		}
+	}
+
+	return path != ""
+}
+
+// getField - get struct value field by name or path.
+func getField(v reflect.Value, path string, o *options) (reflect.Value, error) {
+	// fast path: simple field name
+	if isFieldName(path) {
+		field, err := structField(v, path, false, o)
+		if err != nil {
+			return reflect.Value{}, &AttrError{Path: path, Err: err}
+		}
+
+		return field, nil
+	}
+
+	segs, err := parsePath(path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return getPath(v, segs, o)
+}
+
+// setField - set new value on addressable struct value field by name or path.
+func setField(v reflect.Value, path string, newValue reflect.Value, o *options) error {
+	// fast path: simple field name
+	if isFieldName(path) {
+		field, err := structField(v, path, true, o)
+		if err != nil {
+			return &AttrError{Path: path, Err: err}
+		}
+
+		if err := assign(field, newValue, o); err != nil {
+			return fieldError("", path, err)
+		}
+
+		return nil
+	}
+
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	return setPath(v, segs, newValue, o)
+}
+
+// getPath - walk from struct value along path for reading. Pointers and interfaces are dereferenced.
+func getPath(v reflect.Value, segs []segment, o *options) (reflect.Value, error) {
+	for i, seg := range segs {
+		// dereference intermediate pointers
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, pathError(segs, max(i-1, 0), ErrNilPointer)
+			}
+			v = v.Elem()
+		}
+
+		var err error
+		if seg.name != "" {
+			v, err = structField(v, seg.name, false, o)
+		} else {
+			v, err = elemByKey(v, seg)
+		}
+
+		if err != nil {
+			return reflect.Value{}, pathError(segs, i, err)
+		}
+	}
+
+	return v, nil
+}
+
+// setPath - walk from addressable value along path and assign newValue to the last segment.
+// Nil pointers on the way are allocated.
+func setPath(v reflect.Value, segs []segment, newValue reflect.Value, o *options) error {
+	return setSegment(v, segs, 0, newValue, o)
+}
+
+// setSegment - recursive step of setPath for segment i.
+func setSegment(v reflect.Value, segs []segment, i int, newValue reflect.Value, o *options) error {
+	var (
+		seg  = segs[i]
+		next reflect.Value
+		err  error
+	)
+	// dereference intermediate pointers, allocate nil ones
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case seg.name != "":
+		next, err = structField(v, seg.name, true, o)
+	case v.Kind() == reflect.Map:
+		// map elements are not addressable: change a copy and put it back
+		key, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return pathError(segs, i, err)
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key); cur.IsValid() {
+			elem.Set(cur)
+		}
+
+		if err := setNext(elem, segs, i, newValue, o); err != nil {
+			return err
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(key, elem)
+
+		return nil
+	default:
+		next, err = elemByKey(v, seg)
+	}
+
+	if err != nil {
+		return pathError(segs, i, err)
+	}
+
+	return setNext(next, segs, i, newValue, o)
+}
+
+// setNext - assign newValue if segment i is the last one, else go deeper.
+func setNext(v reflect.Value, segs []segment, i int, newValue reflect.Value, o *options) error {
+	if i < len(segs)-1 {
+		return setSegment(v, segs, i+1, newValue, o)
+	}
+
+	if err := assign(v, newValue, o); err != nil {
+		return pathError(segs, i, err)
+	}
+
+	return nil
+}
+
+// assign - set newValue on field. Ptr field gets newValue by its elem, nil ptr is allocated.
+// Value is converted to field type if convert option is set.
+func assign(field, newValue reflect.Value, o *options) error {
+	target := field.Type()
+	// field ptr check
+	if field.Kind() == reflect.Ptr && newValue.IsValid() && newValue.Type() != target {
+		target = target.Elem()
+	}
+	// convert value
+	if o.convert {
+		converted, err := convertValue(newValue, target)
+		if err != nil {
+			return err
+		}
+		newValue = converted
+	}
+	// types check
+	if !newValue.IsValid() || newValue.Type() != target {
+		return typeError(ErrWrongFieldValueType, target, valueType(newValue))
+	}
+
+	if target != field.Type() {
+		if field.IsNil() {
+			field.Set(reflect.New(target))
+		}
+		field = field.Elem()
+	}
+
+	field.Set(newValue)
+
+	return nil
+}
+
+// structField - get struct field by Go name or tag name. Embedded nil pointers are allocated if 'alloc'.
+func structField(v reflect.Value, name string, alloc bool, o *options) (reflect.Value, error) {
+	// is struct check
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotStruct
+	}
+
+	f, ok := lookupField(v.Type(), name, o)
+	// is field in struct
+	if !ok {
+		return reflect.Value{}, ErrFieldNotInStruct
+	}
+	// is field exported
+	if !f.exported {
+		return reflect.Value{}, ErrUnexportedField
+	}
+	// walk through embedded structs
+	return fieldByInfo(v, f, alloc)
+}
+
+// elemByKey - get slice, array or map element by bracket segment.
+func elemByKey(v reflect.Value, seg segment) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if seg.quoted {
+			return reflect.Value{}, ErrInvalidPath
+		}
+
+		idx, err := strconv.Atoi(seg.key)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidPath
+		}
+
+		if idx < 0 || idx >= v.Len() {
+			return reflect.Value{}, ErrIndexOutOfRange
+		}
+
+		return v.Index(idx), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return reflect.Value{}, ErrKeyNotInMap
+		}
+
+		return elem, nil
+	default:
+		return reflect.Value{}, ErrNotIndexable
+	}
+}
+
+// mapKey - convert segment key to map key type.
+func mapKey(t reflect.Type, seg segment) (reflect.Value, error) {
+	key := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		key.SetString(seg.key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(seg.key, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, ErrInvalidPath
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(seg.key, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, ErrInvalidPath
+		}
+		key.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(seg.key)
+		if err != nil {
+			return reflect.Value{}, ErrInvalidPath
+		}
+		key.SetBool(b)
+	default:
+		return reflect.Value{}, ErrNotIndexable
+	}
+
+	return key, nil
+}
@@ -0,0 +1,405 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type pathAddress struct {
+	City string
+	Zip  *string
+}
+
+type pathCustomer struct {
+	Name    string
+	Address *pathAddress
+}
+
+type PathBase struct {
+	ID int
+}
+
+type pathItem struct {
+	Price float64
+}
+
+type pathOrder struct {
+	PathBase
+	Customer pathCustomer
+	Items    []pathItem
+	Sizes    [3]int
+	Meta     map[string]string
+	Counts   map[int]pathItem
+	Any      interface{}
+	private  pathCustomer
+}
+
+func TestParsePath(t *testing.T) {
+	testCases := []struct {
+		path        string
+		expected    []segment
+		expectedErr error
+		testName    string
+	}{
+		{
+			path:     "Username",
+			expected: []segment{{name: "Username"}},
+			testName: "OK. Field name",
+		},
+		{
+			path:     "Customer.Address.City",
+			expected: []segment{{name: "Customer"}, {name: "Address"}, {name: "City"}},
+			testName: "OK. Dotted path",
+		},
+		{
+			path:     `Items[2].Meta["a.b]"][0]`,
+			expected: []segment{{name: "Items"}, {key: "2"}, {name: "Meta"}, {key: "a.b]", quoted: true}, {key: "0"}},
+			testName: "OK. Index and quoted key",
+		},
+		{
+			path:        "",
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Empty path",
+		},
+		{
+			path:        "Customer.",
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Trailing dot",
+		},
+		{
+			path:        "Items[]",
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Empty index",
+		},
+		{
+			path:        `Meta["region]`,
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Unclosed quote",
+		},
+		{
+			path:        "Items[1]Price",
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. No dot after index",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("parsePath")
+			t.Description("Check func `parsePath`")
+			t.WithParameters(
+				allure.NewParameter("path", testCase.path),
+			)
+
+			actual, err := parsePath(testCase.path)
+			if err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("parsePath error: %v", err))
+			}
+
+			t.Assert().Equal(testCase.expected, actual, "Check parsePath")
+		})
+	}
+}
+
+func TestGetAttrPath(t *testing.T) {
+	zip := "12345"
+	order := pathOrder{
+		PathBase: PathBase{ID: 7},
+		Customer: pathCustomer{Name: "name", Address: &pathAddress{City: "city", Zip: &zip}},
+		Items:    []pathItem{{Price: 1.5}, {Price: 2.5}},
+		Sizes:    [3]int{1, 2, 3},
+		Meta:     map[string]string{"region": "eu"},
+		Counts:   map[int]pathItem{3: {Price: 3.5}},
+		Any:      pathItem{Price: 4.5},
+	}
+
+	testCases := []struct {
+		obj         interface{}
+		fieldName   string
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj:       order,
+			fieldName: "Customer.Address.City",
+			expected:  "city",
+			testName:  "OK. Nested ptr struct",
+		},
+		{
+			obj:       order,
+			fieldName: "Customer.Address.Zip",
+			expected:  zip,
+			testName:  "OK. Nested ptr field",
+		},
+		{
+			obj:       order,
+			fieldName: "PathBase.ID",
+			expected:  7,
+			testName:  "OK. Embedded struct",
+		},
+		{
+			obj:       order,
+			fieldName: "Items[1].Price",
+			expected:  2.5,
+			testName:  "OK. Slice index",
+		},
+		{
+			obj:       order,
+			fieldName: "Sizes[2]",
+			expected:  3,
+			testName:  "OK. Array index",
+		},
+		{
+			obj:       order,
+			fieldName: `Meta["region"]`,
+			expected:  "eu",
+			testName:  "OK. Map string key",
+		},
+		{
+			obj:       order,
+			fieldName: "Counts[3].Price",
+			expected:  3.5,
+			testName:  "OK. Map int key",
+		},
+		{
+			obj:       order,
+			fieldName: "Any.Price",
+			expected:  4.5,
+			testName:  "OK. Interface field",
+		},
+		{
+			obj:         order,
+			fieldName:   "Customer.Phone",
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in nested struct",
+		},
+		{
+			obj:         pathOrder{},
+			fieldName:   "Customer.Address.City",
+			expectedErr: ErrNilPointer,
+			testName:    "ERR. Nil intermediate ptr",
+		},
+		{
+			obj:         order,
+			fieldName:   "Items[5].Price",
+			expectedErr: ErrIndexOutOfRange,
+			testName:    "ERR. Index out of range",
+		},
+		{
+			obj:         order,
+			fieldName:   `Meta["none"]`,
+			expectedErr: ErrKeyNotInMap,
+			testName:    "ERR. Key not in map",
+		},
+		{
+			obj:         order,
+			fieldName:   "Customer[0]",
+			expectedErr: ErrNotIndexable,
+			testName:    "ERR. Not indexable",
+		},
+		{
+			obj:         order,
+			fieldName:   "private.Name",
+			expectedErr: ErrUnexportedField,
+			testName:    "ERR. Unexported intermediate field",
+		},
+		{
+			obj:         order,
+			fieldName:   "Items[x]",
+			expectedErr: ErrInvalidPath,
+			testName:    "ERR. Invalid index",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("GetAttr")
+			t.Description("Check func `GetAttr` with field path")
+			t.WithParameters(
+				allure.NewParameter("fieldName", testCase.fieldName),
+			)
+
+			actual, err := GetAttr(testCase.obj, testCase.fieldName)
+			if err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("GetAttr error: %v", err))
+			}
+
+			t.Assert().Equal(testCase.expected, actual, "Check GetAttr")
+		})
+	}
+}
+
+func TestSetAttrPath(t *testing.T) {
+	testCases := []struct {
+		obj         *pathOrder
+		fieldName   string
+		newValue    interface{}
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj:       &pathOrder{},
+			fieldName: "Customer.Address.City",
+			newValue:  "city",
+			expected:  "city",
+			testName:  "OK. Nil intermediate ptr allocated",
+		},
+		{
+			obj:       &pathOrder{},
+			fieldName: "Customer.Address.Zip",
+			newValue:  "12345",
+			expected:  "12345",
+			testName:  "OK. Nil ptr field allocated",
+		},
+		{
+			obj:       &pathOrder{},
+			fieldName: "ID",
+			newValue:  7,
+			expected:  7,
+			testName:  "OK. Promoted field",
+		},
+		{
+			obj:       &pathOrder{Items: make([]pathItem, 2)},
+			fieldName: "Items[1].Price",
+			newValue:  2.5,
+			expected:  2.5,
+			testName:  "OK. Slice index",
+		},
+		{
+			obj:       &pathOrder{},
+			fieldName: "Sizes[0]",
+			newValue:  1,
+			expected:  1,
+			testName:  "OK. Array index",
+		},
+		{
+			obj:       &pathOrder{},
+			fieldName: `Meta["region"]`,
+			newValue:  "eu",
+			expected:  "eu",
+			testName:  "OK. Nil map allocated",
+		},
+		{
+			obj:       &pathOrder{Counts: map[int]pathItem{3: {Price: 1}}},
+			fieldName: "Counts[3].Price",
+			newValue:  3.5,
+			expected:  3.5,
+			testName:  "OK. Struct in map",
+		},
+		{
+			obj:         &pathOrder{},
+			fieldName:   "Items[0].Price",
+			newValue:    2.5,
+			expectedErr: ErrIndexOutOfRange,
+			testName:    "ERR. Index out of range",
+		},
+		{
+			obj:         &pathOrder{},
+			fieldName:   "Customer.Address.City",
+			newValue:    1,
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong field value type",
+		},
+		{
+			obj:         &pathOrder{},
+			fieldName:   "private.Name",
+			newValue:    "name",
+			expectedErr: ErrUnexportedField,
+			testName:    "ERR. Unexported intermediate field",
+		},
+		{
+			obj:         &pathOrder{},
+			fieldName:   "Customer.Address.Street",
+			newValue:    "street",
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in nested struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetAttr")
+			t.Description("Check func `SetAttr` with field path")
+			t.WithParameters(
+				allure.NewParameter("fieldName", testCase.fieldName),
+				allure.NewParameter("newValue", testCase.newValue),
+			)
+
+			if err := SetAttr(testCase.obj, testCase.newValue, testCase.fieldName); err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("SetAttr error: %v", err))
+				return
+			}
+
+			actual, err := GetAttr(*testCase.obj, testCase.fieldName)
+			t.Assert().NoError(err, "Check GetAttr")
+			t.Assert().Equal(testCase.expected, actual, "Check SetAttr")
+		})
+	}
+}
+
+func TestPathErrorSegment(t *testing.T) {
+	runner.Run(t, "OK. Error names failed segment", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("GetAttr")
+		t.Description("Check that path error names the failed segment")
+
+		_, err := GetAttr(pathOrder{}, "Customer.Address.City")
+		t.Assert().EqualError(err, "GetAttr Customer.Address: nil pointer in field path")
+
+		_, err = GetAttr(pathOrder{Meta: map[string]string{}}, `Meta["x y"]`)
+		t.Assert().EqualError(err, `GetAttr Meta["x y"]: key not in map`)
+	})
+}
+
+func ExampleGetAttr_path() {
+	type Address struct {
+		City string
+	}
+
+	type Order struct {
+		Address *Address
+		Items   []float64
+		Meta    map[string]string
+	}
+
+	order := Order{
+		Address: &Address{City: "city"},
+		Items:   []float64{1.5, 2.5},
+		Meta:    map[string]string{"region": "eu"},
+	}
+
+	for _, path := range []string{"Address.City", "Items[1]", `Meta["region"]`} {
+		value, err := GetAttr(order, path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(value)
+	}
+	// Output:
+	// city
+	// 2.5
+	// eu
+}
@@ -0,0 +1,226 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// redactTagKey - struct tag key of field redaction like `redact:"last4"`.
+const redactTagKey = "redact"
+
+// redactedMask - replacement of fully redacted strings.
+const redactedMask = "***"
+
+// RedactMode - redaction of field value by Redact.
+type RedactMode string
+
+// redaction modes.
+const (
+	RedactFull  RedactMode = "full"  // strings to `***`, other values to zero
+	RedactLast4 RedactMode = "last4" // strings masked except last 4 chars: `************1111`
+	RedactEmail RedactMode = "email" // email local part masked except first char: `j*******@example.com`
+)
+
+// WithRedactFields - Redact fields by paths like `User.Email` with mode, in addition to `redact` tags.
+// Paths without indexes match all slice elements and map values: `Cards.Number` matches `Cards[0].Number`.
+func WithRedactFields(mode RedactMode, paths ...string) Option {
+	return func(o *options) {
+		if o.redact == nil {
+			o.redact = make(map[string]RedactMode, len(paths))
+		}
+
+		for _, path := range paths {
+			o.redact[path] = mode
+		}
+	}
+}
+
+// Redact - mask sensitive fields by tag `redact:"full|last4|email"` or WithRedactFields paths, for logging.
+// Struct is redacted in a deep copy by Clone, ptr struct is redacted in place and returned.
+// Walks into nested structs, ptrs, slices and maps like Walk, unexported fields are skipped.
+// Strings, string ptrs, slices and maps of strings are masked, full mode sets other values to zero.
+// 'obj': struct or ptr struct.
+// 'opts': optional, WithRedactFields, WithTagKey for tag names in paths.
+func Redact[T any](obj T, opts ...Option) (T, error) {
+	var (
+		o        = newOptions(opts)
+		objValue = reflect.ValueOf(obj)
+	)
+	// nil check
+	if !objValue.IsValid() {
+		return obj, objError(opRedact, ErrNotStruct, objValue)
+	}
+
+	visitor := func(field *WalkField) error {
+		mode, ok := redactMode(field, o)
+		if !ok {
+			return nil
+		}
+
+		if err := redactValue(field.Value, mode); err != nil {
+			return fieldError(opRedact, field.Path, err)
+		}
+
+		return SkipField
+	}
+	// ptr struct: in place
+	if objValue.Kind() == reflect.Ptr {
+		if err := Walk(obj, visitor, opts...); err != nil {
+			return obj, opError(opRedact, err)
+		}
+
+		return obj, nil
+	}
+	// struct: redact addressable deep copy
+	cp := reflect.New(objValue.Type())
+	cp.Elem().Set(reflect.ValueOf(Clone(objValue.Interface(), opts...)))
+
+	if err := Walk(cp.Interface(), visitor, opts...); err != nil {
+		return obj, opError(opRedact, err)
+	}
+
+	return cp.Elem().Interface().(T), nil
+}
+
+// redactMode - mode of field by WithRedactFields path, full or without indexes, else by tag.
+func redactMode(field *WalkField, o *options) (RedactMode, bool) {
+	if mode, ok := o.redact[field.Path]; ok {
+		return mode, true
+	}
+
+	if mode, ok := o.redact[stripIndexes(field.Path)]; ok {
+		return mode, true
+	}
+
+	mode, ok := field.StructField.Tag.Lookup(redactTagKey)
+
+	return RedactMode(mode), ok
+}
+
+// redactValue - mask value by mode. Ptrs, interfaces, slices, arrays and maps are masked by elements.
+func redactValue(v reflect.Value, mode RedactMode) error {
+	// mode check
+	if mode != RedactFull && mode != RedactLast4 && mode != RedactEmail {
+		return fmt.Errorf("%w: redact mode %q", ErrInvalidTag, mode)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(maskString(v.String(), mode))
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+
+		return redactValue(v.Elem(), mode)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// interface elem is not addressable: redact a copy and put it back
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+
+		if err := redactValue(elem, mode); err != nil {
+			return err
+		}
+
+		v.Set(elem)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := redactValue(v.Index(i), mode); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// map elements are not addressable: redact a copy and put it back
+		elem := reflect.New(v.Type().Elem()).Elem()
+
+		iter := v.MapRange()
+		for iter.Next() {
+			elem.Set(iter.Value())
+
+			if err := redactValue(elem, mode); err != nil {
+				return err
+			}
+
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	default:
+		if mode != RedactFull {
+			return typeError(ErrWrongFieldValueType, reflect.TypeOf(""), v.Type())
+		}
+
+		v.Set(reflect.Zero(v.Type()))
+	}
+
+	return nil
+}
+
+// maskString - masked string by mode, empty string is kept.
+func maskString(s string, mode RedactMode) string {
+	if s == "" {
+		return s
+	}
+
+	switch mode {
+	case RedactLast4:
+		n := utf8.RuneCountInString(s)
+		if n <= 4 {
+			return strings.Repeat("*", n)
+		}
+
+		runes := []rune(s)
+
+		return strings.Repeat("*", n-4) + string(runes[n-4:])
+	case RedactEmail:
+		at := strings.LastIndexByte(s, '@')
+		if at <= 0 {
+			return redactedMask
+		}
+
+		first, size := utf8.DecodeRuneInString(s)
+
+		return string(first) + strings.Repeat("*", utf8.RuneCountInString(s[size:at])) + s[at:]
+	default:
+		return redactedMask
+	}
+}
+
+// stripIndexes - path without slice indexes and map keys: `Cards[0].Number` to `Cards.Number`.
+func stripIndexes(path string) string {
+	if !strings.Contains(path, "[") {
+		return path
+	}
+
+	var (
+		b      strings.Builder
+		depth  int
+		quoted bool
+	)
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case quoted:
+			// escaped char in quoted map key
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quoted = false
+			}
+		case c == '"' && depth > 0:
+			quoted = true
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case depth == 0:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,278 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type redactCard struct {
+	Number string `json:"number" redact:"last4"`
+	Holder string `json:"holder"`
+	CVV    int    `json:"cvv" redact:"full"`
+}
+
+type redactUser struct {
+	Login    string                `json:"login"`
+	Email    string                `json:"email" redact:"email"`
+	Password *string               `json:"password" redact:"full"`
+	Phones   []string              `json:"phones" redact:"last4"`
+	Cards    []redactCard          `json:"cards"`
+	Tokens   map[string]string     `json:"tokens" redact:"full"`
+	Backup   map[string]redactCard `json:"backup"`
+	Any      interface{}           `json:"any" redact:"full"`
+	Note     string                `json:"note"`
+	secret   string
+}
+
+func TestRedact(t *testing.T) {
+	var (
+		// obj passwords are redacted in place by cases
+		password  = "qwerty"
+		passwords = []string{password, password, password}
+		masked    = "***"
+		user      = redactUser{
+			Login:    "john",
+			Email:    "john.doe@example.com",
+			Password: &password,
+			Phones:   []string{"+79991234567", "123"},
+			Cards:    []redactCard{{Number: "4111111111111111", Holder: "JOHN DOE", CVV: 123}},
+			Tokens:   map[string]string{"api": "token"},
+			Backup:   map[string]redactCard{"main": {Number: "5500000000000004", CVV: 456}},
+			Any:      "any",
+			Note:     "ёжик-1234",
+			secret:   "secret",
+		}
+	)
+
+	testCases := []struct {
+		obj      redactUser
+		opts     []Option
+		expected redactUser
+		testName string
+	}{
+		{
+			obj: redactUser{
+				Login:    "john",
+				Email:    "john.doe@example.com",
+				Password: &passwords[0],
+				Phones:   []string{"+79991234567", "123"},
+				Cards:    []redactCard{{Number: "4111111111111111", Holder: "JOHN DOE", CVV: 123}},
+				Tokens:   map[string]string{"api": "token"},
+				Backup:   map[string]redactCard{"main": {Number: "5500000000000004", CVV: 456}},
+				Any:      "any",
+				Note:     "ёжик-1234",
+				secret:   "secret",
+			},
+			expected: redactUser{
+				Login:    "john",
+				Email:    "j*******@example.com",
+				Password: &masked,
+				Phones:   []string{"********4567", "***"},
+				Cards:    []redactCard{{Number: "************1111", Holder: "JOHN DOE"}},
+				Tokens:   map[string]string{"api": "***"},
+				Backup:   map[string]redactCard{"main": {Number: "************0004"}},
+				Any:      "***",
+				Note:     "ёжик-1234",
+				secret:   "secret",
+			},
+			testName: "OK. Tags",
+		},
+		{
+			obj: redactUser{
+				Login:    "john",
+				Email:    "john.doe@example.com",
+				Password: &passwords[1],
+				Phones:   []string{"+79991234567", "123"},
+				Cards:    []redactCard{{Number: "4111111111111111", Holder: "JOHN DOE", CVV: 123}},
+				Tokens:   map[string]string{"api": "token"},
+				Backup:   map[string]redactCard{"main": {Number: "5500000000000004", CVV: 456}},
+				Any:      "any",
+				Note:     "ёжик-1234",
+				secret:   "secret",
+			},
+			opts: []Option{WithRedactFields(RedactFull, "Login", "Cards.Holder"), WithRedactFields(RedactLast4, "Note")},
+			expected: redactUser{
+				Login:    "***",
+				Email:    "j*******@example.com",
+				Password: &masked,
+				Phones:   []string{"********4567", "***"},
+				Cards:    []redactCard{{Number: "************1111", Holder: "***"}},
+				Tokens:   map[string]string{"api": "***"},
+				Backup:   map[string]redactCard{"main": {Number: "************0004"}},
+				Any:      "***",
+				Note:     "*****1234",
+				secret:   "secret",
+			},
+			testName: "OK. Paths",
+		},
+		{
+			obj: redactUser{
+				Login:    "john",
+				Email:    "john.doe@example.com",
+				Password: &passwords[2],
+				Phones:   []string{"+79991234567", "123"},
+				Cards:    []redactCard{{Number: "4111111111111111", Holder: "JOHN DOE", CVV: 123}},
+				Tokens:   map[string]string{"api": "token"},
+				Backup:   map[string]redactCard{"main": {Number: "5500000000000004", CVV: 456}},
+				Any:      "any",
+				Note:     "ёжик-1234",
+				secret:   "secret",
+			},
+			opts: []Option{WithTagKey("json"), WithRedactFields(RedactFull, "backup[\"main\"].number", "any")},
+			expected: redactUser{
+				Login:    "john",
+				Email:    "j*******@example.com",
+				Password: &masked,
+				Phones:   []string{"********4567", "***"},
+				Cards:    []redactCard{{Number: "************1111", Holder: "JOHN DOE"}},
+				Tokens:   map[string]string{"api": "***"},
+				Backup:   map[string]redactCard{"main": {Number: "***"}},
+				Any:      "***",
+				Note:     "ёжик-1234",
+				secret:   "secret",
+			},
+			testName: "OK. Tag names and full path",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Redact")
+			t.Description("Check func `Redact` by copy and in place")
+			t.WithParameters(allure.NewParameter("opts", len(testCase.opts)))
+
+			copied, err := Redact(testCase.obj, testCase.opts...)
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, copied, "Check redacted copy")
+			t.Assert().Equal(user, testCase.obj, "Check original not changed")
+
+			ptr, err := Redact(&testCase.obj, testCase.opts...)
+			t.Require().NoError(err)
+			t.Assert().Same(&testCase.obj, ptr)
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check redacted in place")
+		})
+	}
+}
+
+func TestRedactErrors(t *testing.T) {
+	testCases := []struct {
+		redact      func() error
+		expectedErr error
+		testName    string
+	}{
+		{
+			redact: func() error {
+				_, err := Redact(struct {
+					Age int `redact:"last4"`
+				}{Age: 1})
+				return err
+			},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Last4 on int",
+		},
+		{
+			redact: func() error {
+				_, err := Redact(struct {
+					Name string `redact:"half"`
+				}{})
+				return err
+			},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Unknown mode",
+		},
+		{
+			redact: func() error {
+				_, err := Redact(1)
+				return err
+			},
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+		{
+			redact: func() error {
+				_, err := Redact[interface{}](nil)
+				return err
+			},
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Redact")
+			t.Description("Check func `Redact` errors")
+
+			err := testCase.redact()
+			t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Redact error: %v", err))
+		})
+	}
+}
+
+func TestStripIndexes(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+		testName string
+	}{
+		{path: "Cards.Number", expected: "Cards.Number", testName: "OK. No indexes"},
+		{path: "Cards[0].Number", expected: "Cards.Number", testName: "OK. Slice index"},
+		{path: `Backup["a].[\"b"].Number`, expected: "Backup.Number", testName: "OK. Quoted map key"},
+		{path: "Grid[0][1]", expected: "Grid", testName: "OK. Nested indexes"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("stripIndexes")
+			t.Description("Check func `stripIndexes`")
+
+			t.Assert().Equal(testCase.expected, stripIndexes(testCase.path))
+		})
+	}
+}
+
+func ExampleRedact() {
+	type Card struct {
+		Number string `redact:"last4"`
+		CVV    int    `redact:"full"`
+	}
+
+	type User struct {
+		Login string
+		Email string `redact:"email"`
+		Cards []Card
+	}
+
+	user := User{Login: "john", Email: "john@example.com", Cards: []Card{{Number: "4111111111111111", CVV: 123}}}
+
+	redacted, err := Redact(user, WithRedactFields(RedactFull, "Login"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", redacted)
+	fmt.Printf("%+v\n", user)
+	// Output:
+	// {Login:*** Email:j***@example.com Cards:[{Number:************1111 CVV:0}]}
+	// {Login:john Email:john@example.com Cards:[{Number:4111111111111111 CVV:123}]}
+}
@@ -0,0 +1,237 @@
+package attrs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ruauka/tools-go/rmath"
+)
+
+// roundTagKey - struct tag key of per-field rounding like `round:"2,half_even"`.
+const roundTagKey = "round"
+
+// RoundMode - rounding mode of RoundStructFloatFields.
+type RoundMode string
+
+// rounding modes.
+const (
+	RoundHalfUp   RoundMode = "half_up"   // halves away from zero, rmath.Round
+	RoundHalfEven RoundMode = "half_even" // halves to even, rmath.RoundHalfEven
+	RoundCeil     RoundMode = "ceil"      // toward +Inf, rmath.RoundUp
+	RoundFloor    RoundMode = "floor"     // toward -Inf, rmath.RoundDown
+	RoundTrunc    RoundMode = "trunc"     // toward zero, rmath.RoundTrunc
+	RoundPython   RoundMode = "py"        // python round of decimal string, rmath.RoundPy
+)
+
+// roundFuncs - rounding func by mode.
+var roundFuncs = map[RoundMode]func(float64, int) float64{
+	RoundHalfUp:   rmath.Round[float64],
+	RoundHalfEven: rmath.RoundHalfEven[float64],
+	RoundCeil:     rmath.RoundUp[float64],
+	RoundFloor:    rmath.RoundDown[float64],
+	RoundTrunc:    rmath.RoundTrunc[float64],
+	RoundPython:   rmath.RoundPy,
+}
+
+// WithRoundMode - RoundStructFloatFields rounds fields without mode in tag by mode, RoundHalfUp by default.
+func WithRoundMode(mode RoundMode) Option {
+	return func(o *options) {
+		o.roundMode = mode
+	}
+}
+
+// maxPyPrecision - max precision of rmath.RoundPy, it formats floats by a table of precisions.
+const maxPyPrecision = 13
+
+// roundSpec - precision and mode of rounding.
+type roundSpec struct {
+	precision int                        // round to
+	round     func(float64, int) float64 // rounding func of mode
+	mode      RoundMode                  // mode of round func, empty for RoundHalfUp
+}
+
+// valid - precision is supported by mode: rmath.RoundPy rounds to 0..maxPyPrecision digits only.
+func (s roundSpec) valid() bool {
+	return s.mode != RoundPython || s.precision >= 0 && s.precision <= maxPyPrecision
+}
+
+// precisionError - precision not supported by mode, wraps sentinel.
+func precisionError(sentinel error, spec roundSpec) error {
+	return fmt.Errorf("%w: round precision %d of mode %q not in 0..%d",
+		sentinel, spec.precision, spec.mode, maxPyPrecision)
+}
+
+// parseRoundTag - rounding of field by tag like `round:"2"`, `round:"4,ceil"` or `round:",half_even"`.
+// Empty precision or mode are taken from parent. Returns false for "-".
+// Precision of mode py must be in 0..13.
+func parseRoundTag(tag reflect.StructTag, parent roundSpec) (roundSpec, bool, error) {
+	value, ok := tag.Lookup(roundTagKey)
+	if !ok {
+		return parent, true, nil
+	}
+
+	if value == "-" {
+		return parent, false, nil
+	}
+
+	spec := parent
+	precision, mode, _ := strings.Cut(value, ",")
+
+	if precision != "" {
+		p, err := strconv.Atoi(strings.TrimSpace(precision))
+		if err != nil {
+			return spec, false, fmt.Errorf("%w: round precision %q", ErrInvalidTag, precision)
+		}
+		spec.precision = p
+	}
+
+	if mode = strings.TrimSpace(mode); mode != "" {
+		round, ok := roundFuncs[RoundMode(mode)]
+		if !ok {
+			return spec, false, fmt.Errorf("%w: round mode %q", ErrInvalidTag, mode)
+		}
+		spec.round, spec.mode = round, RoundMode(mode)
+	}
+	// precision set by tag or inherited by mode set by tag
+	if (precision != "" || parent.mode != RoundPython) && !spec.valid() {
+		return spec, false, precisionError(ErrInvalidTag, spec)
+	}
+
+	return spec, true, nil
+}
+
+// rounder - rounds float values found while walking a struct.
+type rounder struct {
+	o       *options        // tag key
+	visited map[ptrKey]bool // pointers already rounded, protects from cycles
+}
+
+// ptrKey - pointer identity: address and type, struct and its first field share the address.
+type ptrKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// roundStruct - round exported float fields of struct value recursively.
+// Field tag sets rounding of the field and its nested fields.
+func (r *rounder) roundStruct(v reflect.Value, spec roundSpec) error {
+	fields := structFields(v.Type(), r.o)
+	for i := range fields {
+		f := &fields[i]
+		// unexported fields are skipped
+		if !f.exported || !mayHaveFloats(f.typ) {
+			continue
+		}
+		// field rounding by tag
+		fieldSpec, ok, err := parseRoundTag(f.tag, spec)
+		if err != nil {
+			return &AttrError{Path: f.name, Err: err}
+		}
+
+		if !ok {
+			continue
+		}
+		// is embedded ptr nil check
+		field, err := fieldByInfo(v, f, false)
+		if err != nil {
+			continue
+		}
+
+		if err := r.round(field, fieldSpec); err != nil {
+			return prefixError(err, f.name)
+		}
+	}
+
+	return nil
+}
+
+// round - round addressable value: float, ptr, interface, struct, slice, array or map of them.
+func (r *rounder) round(v reflect.Value, spec roundSpec) error {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(spec.round(v.Float(), spec.precision))
+	case reflect.Ptr:
+		// nil ptr and cycle check
+		if v.IsNil() {
+			return nil
+		}
+
+		key := ptrKey{addr: v.Pointer(), typ: v.Type()}
+		if r.visited[key] {
+			return nil
+		}
+
+		if r.visited == nil {
+			r.visited = make(map[ptrKey]bool)
+		}
+		r.visited[key] = true
+
+		return r.round(v.Elem(), spec)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// interface elem is not addressable: round a copy and put it back
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			return r.round(elem, spec)
+		}
+
+		if !mayHaveFloats(elem.Type()) || !v.CanSet() {
+			return nil
+		}
+
+		rounded := reflect.New(elem.Type()).Elem()
+		rounded.Set(elem)
+
+		if err := r.round(rounded, spec); err != nil {
+			return err
+		}
+		v.Set(rounded)
+	case reflect.Struct:
+		return r.roundStruct(v, spec)
+	case reflect.Slice, reflect.Array:
+		if !mayHaveFloats(v.Type().Elem()) {
+			return nil
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := r.round(v.Index(i), spec); err != nil {
+				return prefixError(err, "["+strconv.Itoa(i)+"]")
+			}
+		}
+	case reflect.Map:
+		if v.Len() == 0 || !mayHaveFloats(v.Type().Elem()) {
+			return nil
+		}
+		// map elements are not addressable: round a copy and put it back
+		rounded := reflect.New(v.Type().Elem()).Elem()
+
+		iter := v.MapRange()
+		for iter.Next() {
+			rounded.Set(iter.Value())
+
+			if err := r.round(rounded, spec); err != nil {
+				return prefixError(err, keySegment(iter.Key()).String())
+			}
+			v.SetMapIndex(iter.Key(), rounded)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// mayHaveFloats - false for types which can not contain floats, so they are not walked.
+func mayHaveFloats(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Ptr, reflect.Interface, reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return mayHaveFloats(t.Elem())
+	default:
+		return false
+	}
+}
@@ -0,0 +1,362 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type roundAmount float64
+
+type roundLine struct {
+	Price  float64
+	Weight *float32
+	Amount roundAmount
+}
+
+type roundReport struct {
+	Total   float64
+	Rate    *float64
+	NilRate *float64
+	Lines   []roundLine
+	LinePtr *roundLine
+	NilLine *roundLine
+	ByKey   map[string]float64
+	Nested  map[string]roundLine
+	Matrix  [][]float64
+	Grid    [2][2]float32
+	Any     interface{}
+	Names   []string
+	Next    *roundReport
+	comment string
+	ratio   float64
+}
+
+func TestRoundStructFloatFieldsRecursive(t *testing.T) {
+	newReport := func() *roundReport {
+		rate := 0.12345
+		weight := float32(1.2345)
+
+		report := &roundReport{
+			Total:   1.2345,
+			Rate:    &rate,
+			Lines:   []roundLine{{Price: 1.2345, Weight: &weight, Amount: 2.3456}},
+			LinePtr: &roundLine{Price: 3.4567},
+			ByKey:   map[string]float64{"a": 1.2345},
+			Nested:  map[string]roundLine{"a": {Price: 4.5678}},
+			Matrix:  [][]float64{{1.2345, 2.3456}, {3.4567}},
+			Grid:    [2][2]float32{{1.2345, 2.3456}, {3.4567, 4.5678}},
+			Any:     5.6789,
+			Names:   []string{"name"},
+			comment: "comment",
+			ratio:   1.2345,
+		}
+		report.Next = report
+
+		return report
+	}
+
+	newExpected := func() *roundReport {
+		rate := 0.12
+		weight := float32(1.23)
+
+		report := &roundReport{
+			Total:   1.23,
+			Rate:    &rate,
+			Lines:   []roundLine{{Price: 1.23, Weight: &weight, Amount: 2.35}},
+			LinePtr: &roundLine{Price: 3.46},
+			ByKey:   map[string]float64{"a": 1.23},
+			Nested:  map[string]roundLine{"a": {Price: 4.57}},
+			Matrix:  [][]float64{{1.23, 2.35}, {3.46}},
+			Grid:    [2][2]float32{{1.23, 2.35}, {3.46, 4.57}},
+			Any:     5.68,
+			Names:   []string{"name"},
+			comment: "comment",
+			ratio:   1.2345,
+		}
+		report.Next = report
+
+		return report
+	}
+
+	testCases := []struct {
+		obj       *roundReport
+		precision int
+		expected  *roundReport
+		testName  string
+	}{
+		{
+			obj:       newReport(),
+			precision: 2,
+			expected:  newExpected(),
+			testName:  "OK. Nested structs, ptrs, maps, 2-D slices and cycle",
+		},
+		{
+			obj:       &roundReport{},
+			precision: 2,
+			expected:  &roundReport{},
+			testName:  "OK. Zero struct with nil ptrs and containers",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("RoundStructFloatFields")
+			t.Description("Check func `RoundStructFloatFields` on nested fields")
+			t.WithParameters(
+				allure.NewParameter("precision", testCase.precision),
+			)
+
+			err := RoundStructFloatFields(testCase.obj, testCase.precision)
+			t.Require().NoError(err, "RoundStructFloatFields error")
+
+			// break cycle for comparison
+			t.Assert().Equal(testCase.expected.Next == testCase.expected, testCase.obj.Next == testCase.obj, "Check cycle")
+			testCase.expected.Next, testCase.obj.Next = nil, nil
+
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check RoundStructFloatFields")
+		})
+	}
+}
+
+type roundPayment struct {
+	Amount  float64            `round:"2"`
+	Rate    float64            `round:"6"`
+	Weight  float32            `round:"4,ceil"`
+	Bank    float64            `round:"1,half_even"`
+	Fee     float64            `round:",floor"`
+	Tax     float64            `round:"2,trunc"`
+	Py      float64            `round:"2,py"`
+	Raw     float64            `round:"-"`
+	Lines   []roundLine        `round:"1"`
+	Totals  map[string]float64 `round:"0"`
+	Default float64
+}
+
+func TestRoundStructFloatFieldsTagMode(t *testing.T) {
+	newPayment := func() *roundPayment {
+		return &roundPayment{
+			Amount:  1.23456789,
+			Rate:    1.23456789,
+			Weight:  1.23451,
+			Bank:    0.25,
+			Fee:     -1.2391,
+			Tax:     -1.239,
+			Py:      2.675,
+			Raw:     1.23456789,
+			Lines:   []roundLine{{Price: 1.25}},
+			Totals:  map[string]float64{"a": 2.5},
+			Default: 1.23456789,
+		}
+	}
+
+	testCases := []struct {
+		obj         interface{}
+		precision   int
+		opts        []Option
+		expected    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj:       newPayment(),
+			precision: 3,
+			expected: &roundPayment{
+				Amount:  1.23,
+				Rate:    1.234568,
+				Weight:  1.2346,
+				Bank:    0.2,
+				Fee:     -1.24,
+				Tax:     -1.23,
+				Py:      2.67,
+				Raw:     1.23456789,
+				Lines:   []roundLine{{Price: 1.3}},
+				Totals:  map[string]float64{"a": 3},
+				Default: 1.235,
+			},
+			testName: "OK. Precision and mode by tags",
+		},
+		{
+			obj:       newPayment(),
+			precision: 3,
+			opts:      []Option{WithRoundMode(RoundHalfEven)},
+			expected: &roundPayment{
+				Amount:  1.23,
+				Rate:    1.234568,
+				Weight:  1.2346,
+				Bank:    0.2,
+				Fee:     -1.24,
+				Tax:     -1.23,
+				Py:      2.67,
+				Raw:     1.23456789,
+				Lines:   []roundLine{{Price: 1.2}},
+				Totals:  map[string]float64{"a": 2},
+				Default: 1.235,
+			},
+			testName: "OK. Default mode by option",
+		},
+		{
+			obj:         &struct{ Amount float64 }{Amount: 1.2345},
+			precision:   3,
+			opts:        []Option{WithRoundMode("unknown")},
+			expected:    &struct{ Amount float64 }{Amount: 1.2345},
+			expectedErr: ErrInvalidOption,
+			testName:    "ERR. Unknown default mode",
+		},
+		{
+			obj: &struct {
+				Amount float64 `round:"two"`
+			}{Amount: 1.2345},
+			precision: 3,
+			expected: &struct {
+				Amount float64 `round:"two"`
+			}{Amount: 1.2345},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Invalid precision in tag",
+		},
+		{
+			obj: &struct {
+				Lines []struct {
+					Price float64 `round:"2,up"`
+				}
+			}{Lines: []struct {
+				Price float64 `round:"2,up"`
+			}{{Price: 1.2345}}},
+			precision: 3,
+			expected: &struct {
+				Lines []struct {
+					Price float64 `round:"2,up"`
+				}
+			}{Lines: []struct {
+				Price float64 `round:"2,up"`
+			}{{Price: 1.2345}}},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Unknown mode in nested tag",
+		},
+		{
+			obj:         &struct{ Amount float64 }{Amount: 1.2345},
+			precision:   -1,
+			opts:        []Option{WithRoundMode(RoundPython)},
+			expected:    &struct{ Amount float64 }{Amount: 1.2345},
+			expectedErr: ErrInvalidOption,
+			testName:    "ERR. Negative precision of py mode option",
+		},
+		{
+			obj:         &struct{ Amount float64 }{Amount: 1.2345},
+			precision:   14,
+			opts:        []Option{WithRoundMode(RoundPython)},
+			expected:    &struct{ Amount float64 }{Amount: 1.2345},
+			expectedErr: ErrInvalidOption,
+			testName:    "ERR. Precision of py mode option out of range",
+		},
+		{
+			obj: &struct {
+				Amount float64 `round:"-2,py"`
+			}{Amount: 1234.5},
+			precision: 3,
+			expected: &struct {
+				Amount float64 `round:"-2,py"`
+			}{Amount: 1234.5},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Negative precision of py mode in tag",
+		},
+		{
+			obj: &struct {
+				Amount float64 `round:"14"`
+			}{Amount: 1.2345},
+			precision: 3,
+			opts:      []Option{WithRoundMode(RoundPython)},
+			expected: &struct {
+				Amount float64 `round:"14"`
+			}{Amount: 1.2345},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Precision in tag out of range of py mode option",
+		},
+		{
+			obj: &struct {
+				Amount float64 `round:",py"`
+			}{Amount: 1234.5},
+			precision: -2,
+			expected: &struct {
+				Amount float64 `round:",py"`
+			}{Amount: 1234.5},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Negative precision of py mode set by tag",
+		},
+		{
+			obj: &struct {
+				Amount float64 `round:"-2"`
+			}{Amount: 1234.5},
+			precision: 3,
+			expected: &struct {
+				Amount float64 `round:"-2"`
+			}{Amount: 1200},
+			testName: "OK. Negative precision of half up mode",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("RoundStructFloatFields")
+			t.Description("Check func `RoundStructFloatFields` with `round` tags")
+			t.WithParameters(
+				allure.NewParameter("obj", testCase.obj),
+			)
+
+			if err := RoundStructFloatFields(testCase.obj, testCase.precision, testCase.opts...); err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("RoundStructFloatFields error: %v", err))
+			}
+
+			t.Assert().Equal(testCase.expected, testCase.obj, "Check RoundStructFloatFields")
+		})
+	}
+}
+
+func TestRoundTagErrorPath(t *testing.T) {
+	runner.Run(t, "ERR. Path of nested field", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RoundStructFloatFields")
+		t.Description("Check path of invalid `round` tag error")
+
+		type Line struct {
+			Price float64 `round:"2,up"`
+		}
+
+		obj := &struct{ Lines map[string][]Line }{Lines: map[string][]Line{"a": {{Price: 1}}}}
+
+		err := RoundStructFloatFields(obj, 2)
+		t.Assert().EqualError(err, `RoundStructFloatFields Lines["a"][0].Price: invalid struct tag: round mode "up"`)
+	})
+}
+
+func ExampleWithRoundMode() {
+	type Payment struct {
+		Amount float64 `round:"2"`
+		Rate   float64 `round:"4,ceil"`
+		Raw    float64 `round:"-"`
+		Bank   float64
+	}
+
+	payment := &Payment{Amount: 1.005001, Rate: 0.12341, Raw: 1.23456, Bank: 0.125}
+
+	if err := RoundStructFloatFields(payment, 2, WithRoundMode(RoundHalfEven)); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", *payment)
+	// Output: {Amount:1.01 Rate:0.1235 Raw:1.23456 Bank:0.12}
+}
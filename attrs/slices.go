@@ -0,0 +1,291 @@
+package attrs
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Pluck - field values of slice items as []T, like column of table: `Pluck[float64](orders, "Price")`.
+// Ptr fields are dereferenced like in GetAs. Nil items are ErrNilPointer errors.
+// 'items': slice of structs or struct ptrs.
+// 'path': field name or path like `Customer.Address.City`, `Items[2].Price`, `Meta["region"]`.
+// 'opts': optional, WithConvert to convert field values to T, WithTagKey to resolve names by struct tag.
+func Pluck[T any, S ~[]E, E any](items S, path string, opts ...Option) ([]T, error) {
+	if err := checkItems[E](opPluck); err != nil {
+		return nil, err
+	}
+
+	var (
+		o   = newOptions(opts)
+		out = make([]T, len(items))
+	)
+
+	for i := range items {
+		value, err := itemField[T](items, i, path, o)
+		if err != nil {
+			return nil, opError(opPluck, err)
+		}
+
+		out[i] = value
+	}
+
+	return out, nil
+}
+
+// SetAll - set new value on field of every slice item like SetAttr. Stops on the first error,
+// nil items are ErrNilPointer errors.
+// 'items': slice of structs or struct ptrs, struct items are changed in slice.
+// 'path': field name or path.
+// 'value': new value.
+// 'opts': optional, same as for SetAttr.
+func SetAll[S ~[]E, E any](items S, path string, value interface{}, opts ...Option) error {
+	if err := checkItems[E](opSetAll); err != nil {
+		return err
+	}
+
+	for i := range items {
+		ptr, err := itemPtr(items, i)
+		if err != nil {
+			return opError(opSetAll, err)
+		}
+
+		if err := SetAttr(ptr, value, path, opts...); err != nil {
+			return opError(opSetAll, prefixError(err, indexPath("", i)))
+		}
+	}
+
+	return nil
+}
+
+// GroupBy - slice items grouped by field value, items keep their order in groups:
+// `GroupBy[string](orders, "Region")`. Nil items are ErrNilPointer errors.
+// 'items': slice of structs or struct ptrs.
+// 'path': field name or path, field value is key of type K like in GetAs.
+// 'opts': optional, WithConvert to convert field values to K, WithTagKey to resolve names by struct tag.
+func GroupBy[K comparable, S ~[]E, E any](items S, path string, opts ...Option) (map[K]S, error) {
+	if err := checkItems[E](opGroupBy); err != nil {
+		return nil, err
+	}
+
+	var (
+		o   = newOptions(opts)
+		out = make(map[K]S)
+	)
+
+	for i := range items {
+		key, err := itemField[K](items, i, path, o)
+		if err != nil {
+			return nil, opError(opGroupBy, err)
+		}
+
+		out[key] = append(out[key], items[i])
+	}
+
+	return out, nil
+}
+
+// SortBy - stable sort of slice items by field value in place. Field values are numbers, strings, bools
+// or time.Time, nil ptrs are the least. Nil items are ErrNilPointer errors, items are not changed then.
+// 'items': slice of structs or struct ptrs.
+// 'path': field name or path.
+// 'desc': descending order.
+// 'opts': optional, WithTagKey to resolve names by struct tag.
+func SortBy[S ~[]E, E any](items S, path string, desc bool, opts ...Option) error {
+	if err := checkItems[E](opSortBy); err != nil {
+		return err
+	}
+
+	var (
+		o    = newOptions(opts)
+		keys = make([]reflect.Value, len(items))
+		typ  reflect.Type
+	)
+	// keys of the same ordered type
+	for i := range items {
+		field, err := itemValue(items, i, path, o)
+		if err != nil {
+			return opError(opSortBy, err)
+		}
+
+		keys[i] = orderKey(field)
+		if !keys[i].IsValid() {
+			continue
+		}
+
+		switch itemPath := indexPath("", i) + "." + path; {
+		case typ == nil && !isOrdered(keys[i].Type()):
+			return fieldError(opSortBy, itemPath, typeError(ErrNotOrdered, nil, keys[i].Type()))
+		case typ == nil:
+			typ = keys[i].Type()
+		case keys[i].Type() != typ:
+			return fieldError(opSortBy, itemPath, typeError(ErrWrongFieldValueType, typ, keys[i].Type()))
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		if desc {
+			return compareKeys(keys[j], keys[i])
+		}
+
+		return compareKeys(keys[i], keys[j])
+	})
+
+	sorted := make(S, len(items))
+	for i, j := range order {
+		sorted[i] = items[j]
+	}
+
+	copy(items, sorted)
+
+	return nil
+}
+
+// RoundAll - round float fields of every slice item like RoundStructFloatFields. Stops on the first error,
+// nil items are ErrNilPointer errors.
+// 'items': slice of structs or struct ptrs, struct items are changed in slice.
+// 'precision': round to, for fields without precision in tag.
+// 'opts': optional, same as for RoundStructFloatFields.
+func RoundAll[S ~[]E, E any](items S, precision int, opts ...Option) error {
+	if err := checkItems[E](opRoundAll); err != nil {
+		return err
+	}
+
+	for i := range items {
+		ptr, err := itemPtr(items, i)
+		if err != nil {
+			return opError(opRoundAll, err)
+		}
+
+		if err := RoundStructFloatFields(ptr, precision, opts...); err != nil {
+			return opError(opRoundAll, prefixError(err, indexPath("", i)))
+		}
+	}
+
+	return nil
+}
+
+// checkItems - slice item type E is struct or struct ptr.
+func checkItems[E any](op string) error {
+	t := reflect.TypeFor[E]()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// is struct check
+	if t.Kind() != reflect.Struct {
+		return &AttrError{Op: op, Err: ErrNotStruct, Actual: reflect.TypeFor[E]()}
+	}
+
+	return nil
+}
+
+// itemPtr - ptr to struct of slice item i: address of struct item or struct ptr item.
+func itemPtr[S ~[]E, E any](items S, i int) (interface{}, error) {
+	item := reflect.ValueOf(items).Index(i)
+	if item.Kind() != reflect.Ptr {
+		return item.Addr().Interface(), nil
+	}
+	// nil item check
+	if item.IsNil() {
+		return nil, &AttrError{Path: indexPath("", i), Err: ErrNilPointer}
+	}
+
+	return item.Interface(), nil
+}
+
+// itemValue - field value of slice item i by path.
+func itemValue[S ~[]E, E any](items S, i int, path string, o *options) (reflect.Value, error) {
+	item := reflect.ValueOf(items).Index(i)
+	if item.Kind() == reflect.Ptr {
+		// nil item check
+		if item.IsNil() {
+			return reflect.Value{}, &AttrError{Path: indexPath("", i), Err: ErrNilPointer}
+		}
+		item = item.Elem()
+	}
+
+	field, err := getField(item, path, o)
+	if err != nil {
+		return reflect.Value{}, prefixError(err, indexPath("", i))
+	}
+
+	return field, nil
+}
+
+// itemField - field value of slice item i as type T.
+func itemField[T any, S ~[]E, E any](items S, i int, path string, o *options) (T, error) {
+	field, err := itemValue(items, i, path, o)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	value, err := valueAs[T](field, o)
+	if err != nil {
+		return value, fieldError("", indexPath("", i)+"."+path, err)
+	}
+
+	return value, nil
+}
+
+// orderKey - value to order by: ptrs and interfaces are dereferenced, invalid value for nil.
+func orderKey(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// isOrdered - values of type t can be compared by compareKeys.
+func isOrdered(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	default:
+		return t == timeType
+	}
+}
+
+// compareKeys - compare order keys of the same ordered type, invalid value is the least.
+func compareKeys(a, b reflect.Value) int {
+	// nil check
+	if !a.IsValid() || !b.IsValid() {
+		return cmp.Compare(boolInt(a.IsValid()), boolInt(b.IsValid()))
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	default:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+}
+
+// boolInt - 1 for true, 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,485 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type sliceCustomer struct {
+	Name string
+}
+
+type sliceOrder struct {
+	ID        int
+	Region    string `json:"region"`
+	Amount    float64
+	Price     float64 `round:"1"`
+	Discount  *float64
+	Status    string
+	Customer  *sliceCustomer
+	CreatedAt time.Time
+	Tags      []string
+	note      string
+}
+
+func TestPluck(t *testing.T) {
+	var (
+		discount = 0.1
+		orders   = []sliceOrder{
+			{ID: 1, Amount: 20, Customer: &sliceCustomer{Name: "john"}},
+			{ID: 2, Amount: 10, Customer: &sliceCustomer{Name: "jane"}},
+			{ID: 3, Amount: 30},
+			{ID: 4, Amount: 10},
+		}
+	)
+
+	testCases := []struct {
+		items       interface{}
+		path        string
+		opts        []Option
+		expected    interface{}
+		expectedErr string
+		testName    string
+	}{
+		{
+			items:    orders,
+			path:     "Amount",
+			expected: []float64{20, 10, 30, 10},
+			testName: "OK. Structs",
+		},
+		{
+			items:    []*sliceOrder{{Amount: 20}, {Amount: 10}, {Amount: 30}, {Amount: 10}},
+			path:     "Amount",
+			expected: []float64{20, 10, 30, 10},
+			testName: "OK. Struct ptrs",
+		},
+		{
+			items:    []sliceOrder{{Discount: &discount}, {Discount: &discount}},
+			path:     "Discount",
+			expected: []float64{0.1, 0.1},
+			testName: "OK. Ptr field deref",
+		},
+		{
+			items:    orders[:2],
+			path:     "ID",
+			opts:     []Option{WithConvert()},
+			expected: []float64{1, 2},
+			testName: "OK. Convert",
+		},
+		{
+			items:       orders[:2],
+			path:        "Customer.Name",
+			expected:    []float64(nil),
+			expectedErr: "Pluck [0].Customer.Name: wrong field value type (expected float64, got string)",
+			testName:    "ERR. Wrong type",
+		},
+		{
+			items:       []sliceOrder{{}},
+			path:        "Customer.Name",
+			expectedErr: "Pluck [0].Customer: nil pointer in field path",
+			testName:    "ERR. Nil ptr in path",
+		},
+		{
+			items:       []*sliceOrder{{}, nil},
+			path:        "Amount",
+			expectedErr: "Pluck [1]: nil pointer in field path",
+			testName:    "ERR. Nil item",
+		},
+		{
+			items:       orders,
+			path:        "note",
+			expectedErr: "Pluck [0].note: field not exported",
+			testName:    "ERR. Unexported field",
+		},
+		{
+			items:       []int{1},
+			path:        "Amount",
+			expectedErr: "Pluck: not a struct (got int)",
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Pluck")
+			t.Description("Check func `Pluck`")
+			t.WithParameters(allure.NewParameter("path", testCase.path))
+
+			var (
+				actual []float64
+				err    error
+			)
+
+			switch items := testCase.items.(type) {
+			case []sliceOrder:
+				actual, err = Pluck[float64](items, testCase.path, testCase.opts...)
+			case []*sliceOrder:
+				actual, err = Pluck[float64](items, testCase.path, testCase.opts...)
+			case []int:
+				actual, err = Pluck[float64](items, testCase.path, testCase.opts...)
+			}
+
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, actual, "Check Pluck")
+		})
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	testCases := []struct {
+		items       interface{}
+		path        string
+		value       interface{}
+		opts        []Option
+		expected    interface{}
+		expectedErr string
+		testName    string
+	}{
+		{
+			items:    []sliceOrder{{ID: 1}, {ID: 2}},
+			path:     "Status",
+			value:    "done",
+			expected: []sliceOrder{{ID: 1, Status: "done"}, {ID: 2, Status: "done"}},
+			testName: "OK. Field",
+		},
+		{
+			items:    []*sliceOrder{{ID: 1}, {ID: 2}},
+			path:     "Status",
+			value:    "done",
+			expected: []*sliceOrder{{ID: 1, Status: "done"}, {ID: 2, Status: "done"}},
+			testName: "OK. Field of struct ptrs",
+		},
+		{
+			items:    []*sliceOrder{{Region: "us"}, {Region: "asia"}},
+			path:     "region",
+			value:    "eu",
+			opts:     []Option{WithTagKey("json")},
+			expected: []*sliceOrder{{Region: "eu"}, {Region: "eu"}},
+			testName: "OK. Tag name",
+		},
+		{
+			items:    []sliceOrder{{Customer: &sliceCustomer{Name: "john"}}, {}},
+			path:     "Customer.Name",
+			value:    "anna",
+			expected: []sliceOrder{{Customer: &sliceCustomer{Name: "anna"}}, {Customer: &sliceCustomer{Name: "anna"}}},
+			testName: "OK. Nil ptr in path",
+		},
+		{
+			items:       []sliceOrder{{Amount: 20}},
+			path:        "Amount",
+			value:       "10",
+			expectedErr: "SetAll [0].Amount: wrong field value type (expected float64, got string)",
+			testName:    "ERR. Wrong type",
+		},
+		{
+			items:       []*sliceOrder{{}, nil},
+			path:        "Status",
+			value:       "done",
+			expectedErr: "SetAll [1]: nil pointer in field path",
+			testName:    "ERR. Nil item",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetAll")
+			t.Description("Check func `SetAll`")
+			t.WithParameters(
+				allure.NewParameter("path", testCase.path),
+				allure.NewParameter("value", testCase.value),
+			)
+
+			var err error
+
+			switch items := testCase.items.(type) {
+			case []sliceOrder:
+				err = SetAll(items, testCase.path, testCase.value, testCase.opts...)
+			case []*sliceOrder:
+				err = SetAll(items, testCase.path, testCase.value, testCase.opts...)
+			}
+
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, testCase.items, "Check SetAll")
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	runner.Run(t, "OK. Groups keep order", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("GroupBy")
+		t.Description("Check func `GroupBy`")
+
+		orders := []sliceOrder{
+			{ID: 1, Region: "eu", Amount: 20},
+			{ID: 2, Region: "us", Amount: 10},
+			{ID: 3, Region: "eu", Amount: 30},
+			{ID: 4, Region: "asia", Amount: 10},
+		}
+
+		groups, err := GroupBy[string](orders, "Region")
+		t.Require().NoError(err)
+		t.Assert().Equal(map[string][]sliceOrder{
+			"eu":   {orders[0], orders[2]},
+			"us":   {orders[1]},
+			"asia": {orders[3]},
+		}, groups)
+
+		ptrs := []*sliceOrder{&orders[0], &orders[1], &orders[2], &orders[3]}
+
+		ptrGroups, err := GroupBy[float64](ptrs, "Amount")
+		t.Require().NoError(err)
+		t.Assert().Equal(map[float64][]*sliceOrder{
+			10: {ptrs[1], ptrs[3]},
+			20: {ptrs[0]},
+			30: {ptrs[2]},
+		}, ptrGroups)
+	})
+
+	runner.Run(t, "OK. Empty slice", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("GroupBy")
+		t.Description("Check func `GroupBy` with empty slice")
+
+		groups, err := GroupBy[string]([]sliceOrder(nil), "Region")
+		t.Require().NoError(err)
+		t.Assert().Empty(groups)
+	})
+
+	runner.Run(t, "ERR. Wrong key type", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("GroupBy")
+		t.Description("Check func `GroupBy` with wrong key type")
+
+		_, err := GroupBy[int]([]sliceOrder{{Region: "eu"}}, "Region")
+		t.Assert().EqualError(err, "GroupBy [0].Region: wrong field value type (expected int, got string)")
+	})
+
+	runner.Run(t, "ERR. Nil item", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("GroupBy")
+		t.Description("Check func `GroupBy` with nil item")
+
+		_, err := GroupBy[string]([]*sliceOrder{{}, nil}, "Region")
+		t.Assert().ErrorIs(err, ErrNilPointer)
+		t.Assert().EqualError(err, "GroupBy [1]: nil pointer in field path")
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	var (
+		ts     = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		low    = 0.1
+		high   = 0.2
+		orders = []sliceOrder{
+			{ID: 1, Region: "eu", Amount: 20, Discount: &high, CreatedAt: ts.Add(time.Hour)},
+			{ID: 2, Region: "us", Amount: 10, CreatedAt: ts},
+			{ID: 3, Region: "eu", Amount: 30, Discount: &low, CreatedAt: ts.Add(2 * time.Hour)},
+			{ID: 4, Region: "asia", Amount: 10, CreatedAt: ts.Add(-time.Hour)},
+		}
+	)
+
+	testCases := []struct {
+		ptrs        []*sliceOrder // struct ptrs with nil, checked instead of orders
+		path        string
+		desc        bool
+		opts        []Option
+		expected    []int
+		expectedErr string
+		testName    string
+	}{
+		{path: "Amount", expected: []int{2, 4, 1, 3}, testName: "OK. Float asc, stable"},
+		{path: "Amount", desc: true, expected: []int{3, 1, 2, 4}, testName: "OK. Float desc, stable"},
+		{path: "Region", expected: []int{4, 1, 3, 2}, testName: "OK. String"},
+		{path: "region", opts: []Option{WithTagKey("json")}, expected: []int{4, 1, 3, 2}, testName: "OK. Tag name"},
+		{path: "CreatedAt", expected: []int{4, 2, 1, 3}, testName: "OK. Time"},
+		{path: "Discount", expected: []int{2, 4, 3, 1}, testName: "OK. Nil ptrs first"},
+		{path: "Discount", desc: true, expected: []int{1, 3, 2, 4}, testName: "OK. Nil ptrs last in desc"},
+		{
+			path:        "Tags",
+			expectedErr: "SortBy [0].Tags: field type not ordered (got []string)",
+			testName:    "ERR. Not ordered",
+		},
+		{
+			path:        "Unknown",
+			expectedErr: "SortBy [0].Unknown: field not in struct",
+			testName:    "ERR. Field not in struct",
+		},
+		{
+			ptrs:        []*sliceOrder{{ID: 2}, nil, {ID: 1}},
+			path:        "ID",
+			expectedErr: "SortBy [1]: nil pointer in field path",
+			testName:    "ERR. Nil item",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SortBy")
+			t.Description("Check func `SortBy` on structs and struct ptrs")
+			t.WithParameters(
+				allure.NewParameter("path", testCase.path),
+				allure.NewParameter("desc", testCase.desc),
+			)
+
+			if testCase.ptrs != nil {
+				ptrs := append([]*sliceOrder(nil), testCase.ptrs...)
+
+				err := SortBy(ptrs, testCase.path, testCase.desc, testCase.opts...)
+				t.Assert().EqualError(err, testCase.expectedErr)
+				t.Assert().Equal(testCase.ptrs, ptrs, "Check items not changed")
+
+				return
+			}
+
+			items := append([]sliceOrder(nil), orders...)
+			copies := append([]sliceOrder(nil), orders...)
+			ptrs := []*sliceOrder{&copies[0], &copies[1], &copies[2], &copies[3]}
+
+			err := SortBy(items, testCase.path, testCase.desc, testCase.opts...)
+			ptrErr := SortBy(ptrs, testCase.path, testCase.desc, testCase.opts...)
+
+			if testCase.expectedErr != "" {
+				t.Assert().EqualError(err, testCase.expectedErr)
+				t.Assert().EqualError(ptrErr, testCase.expectedErr)
+				t.Assert().Equal(orders, items, "Check items not changed")
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Require().NoError(ptrErr)
+
+			ids, err := Pluck[int](items, "ID")
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, ids, "Check SortBy")
+
+			ptrIDs, err := Pluck[int](ptrs, "ID")
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expected, ptrIDs, "Check SortBy by ptrs")
+		})
+	}
+
+	runner.Run(t, "ERR. Different types", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SortBy")
+		t.Description("Check func `SortBy` with different types of interface field")
+
+		type item struct {
+			Value interface{}
+		}
+
+		err := SortBy([]item{{Value: 1}, {Value: nil}, {Value: "a"}}, "Value", false)
+		t.Assert().EqualError(err, "SortBy [2].Value: wrong field value type (expected int, got string)")
+	})
+}
+
+func TestRoundAll(t *testing.T) {
+	runner.Run(t, "OK. Structs and struct ptrs", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RoundAll")
+		t.Description("Check func `RoundAll`")
+
+		orders := []sliceOrder{{Price: 1.555}, {Price: 2.444}, {Price: 3.333}, {Price: 4.449}}
+		t.Require().NoError(RoundAll(orders, 2))
+
+		prices, err := Pluck[float64](orders, "Price")
+		t.Require().NoError(err)
+		t.Assert().Equal([]float64{1.6, 2.4, 3.3, 4.4}, prices)
+
+		ptrs := []*sliceOrder{{Amount: 1.555}, {Amount: 2.445}}
+		t.Require().NoError(RoundAll(ptrs, 2, WithRoundMode(RoundFloor)))
+		t.Assert().Equal(1.55, ptrs[0].Amount)
+		t.Assert().Equal(2.44, ptrs[1].Amount)
+	})
+
+	runner.Run(t, "ERR. Nil item", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RoundAll")
+		t.Description("Check func `RoundAll` with nil item")
+
+		ptrs := []*sliceOrder{{Amount: 1.555}, nil, {Amount: 2.445}}
+
+		err := RoundAll(ptrs, 2)
+		t.Assert().ErrorIs(err, ErrNilPointer)
+		t.Assert().EqualError(err, "RoundAll [1]: nil pointer in field path")
+		t.Assert().Equal(2.445, ptrs[2].Amount)
+	})
+
+	runner.Run(t, "ERR. Invalid tag", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RoundAll")
+		t.Description("Check func `RoundAll` with invalid round tag")
+
+		type item struct {
+			Value float64 `round:"two"`
+		}
+
+		err := RoundAll([]item{{Value: 1}}, 2)
+		t.Assert().ErrorIs(err, ErrInvalidTag)
+		t.Assert().Contains(err.Error(), "RoundAll [0].Value")
+	})
+}
+
+func ExamplePluck() {
+	type Order struct {
+		Region string
+		Amount float64
+	}
+
+	orders := []Order{{Region: "eu", Amount: 20.555}, {Region: "us", Amount: 10}, {Region: "eu", Amount: 30}}
+
+	if err := RoundAll(orders, 2); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := SortBy(orders, "Amount", true); err != nil {
+		log.Fatal(err)
+	}
+
+	amounts, err := Pluck[float64](orders, "Amount")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	groups, err := GroupBy[string](orders, "Region")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(amounts)
+	fmt.Println(groups["eu"])
+	// Output:
+	// [30 20.56 10]
+	// [{eu 30} {eu 20.56}]
+}
@@ -0,0 +1,152 @@
+package attrs
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagIndex - struct fields by tag names.
+type tagIndex struct {
+	fields []fieldInfo           // fields in struct order
+	byName map[string]*fieldInfo // tag name to field
+}
+
+// WithTagKey - resolve field names by struct tag `key` (json, yaml, db, attr, ...) instead of Go names.
+// Fields without tag use Go name, fields with tag "-" and unexported fields are skipped,
+// tag options after comma (omitempty, ...) are ignored. Untagged embedded structs are flattened.
+func WithTagKey(key string) Option {
+	return func(o *options) {
+		o.tagKey = key
+	}
+}
+
+// tagName - field name from tag like `json:"name,omitempty"`. Returns false for "-".
+func tagName(sf reflect.StructField, key string) (string, bool) {
+	tag := sf.Tag.Get(key)
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, true
+}
+
+// tagCandidate - field found while building tagIndex.
+type tagCandidate struct {
+	fieldInfo
+	depth  int  // embedding depth
+	tagged bool // name is from tag
+}
+
+// buildTagIndex - collect fields of struct type t by tag names.
+// Name conflicts are resolved like in encoding/json: the shallowest field wins, then the tagged one,
+// otherwise the name is ambiguous and dropped.
+func buildTagIndex(root reflect.Type, key string) *tagIndex {
+	var (
+		candidates []tagCandidate
+		walk       func(t reflect.Type, index []int, depth int, visited map[reflect.Type]bool)
+	)
+
+	walk = func(t reflect.Type, index []int, depth int, visited map[reflect.Type]bool) {
+		visited[t] = true
+		defer delete(visited, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+
+			name, ok := tagName(sf, key)
+			if !ok {
+				continue
+			}
+
+			fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+			// untagged embedded struct: flatten its fields
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				if !visited[ft] {
+					walk(ft, fieldIndex, depth+1, visited)
+				}
+				continue
+			}
+
+			if !sf.IsExported() {
+				continue
+			}
+
+			if name == "" {
+				candidates = append(candidates, tagCandidate{fieldInfo: newFieldInfo(root, sf.Name, fieldIndex), depth: depth})
+				continue
+			}
+
+			candidate := tagCandidate{fieldInfo: newFieldInfo(root, name, fieldIndex), depth: depth, tagged: true}
+
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	walk(root, nil, 0, map[reflect.Type]bool{})
+
+	// dominant field for every name
+	byName := make(map[string][]int, len(candidates))
+	for i, c := range candidates {
+		byName[c.name] = append(byName[c.name], i)
+	}
+
+	dominant := make(map[string]int, len(byName))
+	for name, group := range byName {
+		if i, ok := dominantField(candidates, group); ok {
+			dominant[name] = i
+		}
+	}
+
+	idx := &tagIndex{byName: make(map[string]*fieldInfo, len(dominant))}
+	for i, c := range candidates {
+		if j, ok := dominant[c.name]; !ok || j != i {
+			continue
+		}
+
+		idx.fields = append(idx.fields, c.fieldInfo)
+	}
+
+	for i := range idx.fields {
+		idx.byName[idx.fields[i].name] = &idx.fields[i]
+	}
+
+	return idx
+}
+
+// dominantField - the shallowest candidate from group, tagged one if several. False if ambiguous.
+func dominantField(candidates []tagCandidate, group []int) (int, bool) {
+	var (
+		best     = group[0]
+		count    = 1
+		tagCount int
+	)
+
+	for _, i := range group[1:] {
+		switch {
+		case candidates[i].depth < candidates[best].depth:
+			best, count = i, 1
+		case candidates[i].depth == candidates[best].depth:
+			count++
+		}
+	}
+
+	if count == 1 {
+		return best, true
+	}
+
+	for _, i := range group {
+		if candidates[i].depth == candidates[best].depth && candidates[i].tagged {
+			best = i
+			tagCount++
+		}
+	}
+
+	return best, tagCount == 1
+}
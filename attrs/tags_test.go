@@ -0,0 +1,246 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type TagAudit struct {
+	CreatedBy string `json:"created_by" db:"created_by"`
+	Version   int    `json:"version"`
+}
+
+type tagAddress struct {
+	City string `json:"city" db:"city_name"`
+}
+
+type tagUser struct {
+	TagAudit
+	ID       int         `json:"id" db:"user_id"`
+	Username string      `json:"username,omitempty" db:"username"`
+	Password string      `json:"-" db:"password"`
+	Email    string      `db:"email"`
+	Version  int         `json:"ver"`
+	Address  *tagAddress `json:"address" db:"address"`
+	Score    float64     `json:"score" attr:"-"`
+	Ratio    float64     `json:"ratio"`
+	secret   string
+}
+
+func TestBuildTagIndex(t *testing.T) {
+	type Left struct {
+		Name string `attr:"name"`
+	}
+
+	type Right struct {
+		Name string `attr:"name"`
+	}
+
+	type Ambiguous struct {
+		Left
+		Right
+		ID int `attr:"id"`
+	}
+
+	type TaggedWins struct {
+		Left
+		Other struct {
+			Name string
+		}
+		Right *Right `attr:"right"`
+	}
+
+	testCases := []struct {
+		obj      interface{}
+		key      string
+		expected []fieldInfo
+		testName string
+	}{
+		{
+			obj: tagUser{},
+			key: "json",
+			expected: []fieldInfo{
+				{name: "created_by", index: []int{0, 0}},
+				{name: "version", index: []int{0, 1}},
+				{name: "id", index: []int{1}},
+				{name: "username", index: []int{2}},
+				{name: "Email", index: []int{4}},
+				{name: "ver", index: []int{5}},
+				{name: "address", index: []int{6}},
+				{name: "score", index: []int{7}},
+				{name: "ratio", index: []int{8}},
+			},
+			testName: "OK. Json tags, embedded struct flattened",
+		},
+		{
+			obj: tagUser{},
+			key: "db",
+			expected: []fieldInfo{
+				{name: "created_by", index: []int{0, 0}},
+				{name: "user_id", index: []int{1}},
+				{name: "username", index: []int{2}},
+				{name: "password", index: []int{3}},
+				{name: "email", index: []int{4}},
+				{name: "Version", index: []int{5}},
+				{name: "address", index: []int{6}},
+				{name: "Score", index: []int{7}},
+				{name: "Ratio", index: []int{8}},
+			},
+			testName: "OK. Db tags, shallow field wins",
+		},
+		{
+			obj:      Ambiguous{},
+			key:      "attr",
+			expected: []fieldInfo{{name: "id", index: []int{2}}},
+			testName: "OK. Ambiguous names dropped",
+		},
+		{
+			obj: TaggedWins{},
+			key: "attr",
+			expected: []fieldInfo{
+				{name: "name", index: []int{0, 0}},
+				{name: "Other", index: []int{1}},
+				{name: "right", index: []int{2}},
+			},
+			testName: "OK. Tagged embedded struct is not flattened",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("buildTagIndex")
+			t.Description("Check func `buildTagIndex`")
+			t.WithParameters(
+				allure.NewParameter("key", testCase.key),
+			)
+
+			typ := reflect.TypeOf(testCase.obj)
+			actual := getTypeInfo(typ).tagIndex(typ, testCase.key)
+			t.Assert().Equal(len(testCase.expected), len(actual.fields), "Check buildTagIndex")
+
+			for i, f := range actual.fields {
+				t.Assert().Equal(testCase.expected[i].name, f.name, "Check field name")
+				t.Assert().Equal(testCase.expected[i].index, f.index, "Check field index")
+			}
+
+			t.Assert().Same(actual, getTypeInfo(typ).tagIndex(typ, testCase.key), "Check cache")
+		})
+	}
+}
+
+func TestGetSetAttrTag(t *testing.T) {
+	testCases := []struct {
+		key         string
+		fieldName   string
+		newValue    interface{}
+		expectedErr error
+		testName    string
+	}{
+		{key: "json", fieldName: "username", newValue: "name", testName: "OK. Json name with omitempty"},
+		{key: "json", fieldName: "created_by", newValue: "admin", testName: "OK. Embedded struct field"},
+		{key: "json", fieldName: "address.city", newValue: "city", testName: "OK. Nested path by tags"},
+		{key: "db", fieldName: "address.city_name", newValue: "city", testName: "OK. Nested path by db tags"},
+		{key: "json", fieldName: "Email", newValue: "email", testName: "OK. Untagged field by Go name"},
+		{key: "json", fieldName: "Username", newValue: "name", expectedErr: ErrFieldNotInStruct, testName: "ERR. Go name of tagged field"},
+		{key: "json", fieldName: "Password", newValue: "pass", expectedErr: ErrFieldNotInStruct, testName: "ERR. Field with tag -"},
+		{key: "json", fieldName: "secret", newValue: "secret", expectedErr: ErrFieldNotInStruct, testName: "ERR. Unexported field"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("SetAttr")
+			t.Description("Check funcs `SetAttr` and `GetAttr` with `WithTagKey` option")
+			t.WithParameters(
+				allure.NewParameter("key", testCase.key),
+				allure.NewParameter("fieldName", testCase.fieldName),
+			)
+
+			user := &tagUser{}
+			if err := SetAttr(user, testCase.newValue, testCase.fieldName, WithTagKey(testCase.key)); err != nil {
+				t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("SetAttr error: %v", err))
+				return
+			}
+
+			actual, err := GetAttr(*user, testCase.fieldName, WithTagKey(testCase.key))
+			t.Assert().NoError(err, "Check GetAttr")
+			t.Assert().Equal(testCase.newValue, actual, "Check SetAttr")
+		})
+	}
+}
+
+func TestSetStructAttrsTag(t *testing.T) {
+	runner.Run(t, "OK. Fields matched by json names", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("SetStructAttrs")
+		t.Description("Check func `SetStructAttrs` with `WithTagKey` option")
+
+		type Patch struct {
+			Name     *string `json:"username"`
+			Password *string `json:"-"`
+			Ver      int     `json:"ver"`
+		}
+
+		name, password := "new_name", "new_password"
+		user := &tagUser{Username: "name", Password: "password", Version: 1}
+
+		err := SetStructAttrs(user, Patch{Name: &name, Password: &password, Ver: 2}, WithTagKey("json"))
+		t.Require().NoError(err)
+		t.Assert().Equal(&tagUser{Username: name, Password: "password", Version: 2}, user)
+	})
+}
+
+func TestRoundStructFloatFieldsTag(t *testing.T) {
+	runner.Run(t, "OK. Fields with tag - and unexported skipped", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RoundStructFloatFields")
+		t.Description("Check func `RoundStructFloatFields` with `WithTagKey` option")
+
+		user := &tagUser{Score: 1.2345, Ratio: 1.2345, secret: "secret"}
+
+		err := RoundStructFloatFields(user, 2, WithTagKey("attr"))
+		t.Require().NoError(err)
+		t.Assert().Equal(&tagUser{Score: 1.2345, Ratio: 1.23, secret: "secret"}, user)
+	})
+}
+
+func ExampleWithTagKey() {
+	type User struct {
+		Username string `json:"username,omitempty"`
+		Password string `json:"-"`
+	}
+
+	user := &User{}
+
+	if err := SetAttr(user, "username", "username", WithTagKey("json")); err != nil {
+		log.Fatal(err)
+	}
+
+	value, err := GetAttr(*user, "username", WithTagKey("json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = GetAttr(*user, "Password", WithTagKey("json"))
+
+	fmt.Println(value)
+	fmt.Println(err)
+	// Output:
+	// username
+	// GetAttr Password: field not in struct
+}
@@ -0,0 +1,155 @@
+package attrs
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Tracked - struct with change tracking. Fields are changed by Set and Merge, changed paths are dirty
+// until Reset or Rollback. Path set back to its original value is not dirty. Not safe for concurrent use.
+type Tracked[T any] struct {
+	value    T
+	original T        // clone of value at Track or the last Reset
+	dirty    []string // changed paths in order of the first change
+	opts     []Option
+	o        *options
+}
+
+// Track - start tracking changes of struct.
+// 'value': struct, its clone is kept as original.
+// 'opts': optional, used by Set, Merge and Clone, also for values comparison: WithConvert, WithTagKey,
+// WithFloatPrecision, WithUnexported.
+func Track[T any](value T, opts ...Option) (*Tracked[T], error) {
+	// is struct check
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Struct {
+		return nil, &AttrError{Op: opTrack, Err: ErrNotStruct, Actual: t}
+	}
+
+	return &Tracked[T]{value: value, original: Clone(value, opts...), opts: opts, o: newOptions(opts)}, nil
+}
+
+// Value - current struct. Its ptrs, slices and maps are shared, changes through them are not tracked.
+func (t *Tracked[T]) Value() T {
+	return t.value
+}
+
+// Original - struct at Track or the last Reset.
+func (t *Tracked[T]) Original() T {
+	return t.original
+}
+
+// Set - set new value on field like SetAttr and mark the path dirty.
+// 'path': field name or path like `Customer.Address.City`, `Items[2].Price`, `Meta["region"]`.
+// 'value': new value.
+func (t *Tracked[T]) Set(path string, value interface{}) error {
+	if err := SetAttr(&t.value, value, path, t.opts...); err != nil {
+		return err
+	}
+
+	t.mark(path)
+
+	return nil
+}
+
+// Merge - update fields from new struct like SetStructAttrs and mark changed fields dirty by Diff paths,
+// so fields of partial update with the same values are not dirty. Fields changed before error are marked too.
+// 'newObj': value struct, fields can be ptr or value.
+// 'opts': optional merge policies, added to Track opts.
+func (t *Tracked[T]) Merge(newObj interface{}, opts ...Option) error {
+	before := Clone(t.value, t.opts...)
+
+	err := SetStructAttrs(&t.value, newObj, slices.Concat(t.opts, opts)...)
+
+	changes, diffErr := Diff(&before, &t.value, t.opts...)
+	if diffErr != nil {
+		return diffErr
+	}
+
+	for _, change := range changes {
+		t.mark(change.Path)
+	}
+
+	return err
+}
+
+// Dirty - changed paths in order of the first change.
+func (t *Tracked[T]) Dirty() []string {
+	return slices.Clone(t.dirty)
+}
+
+// Changes - dirty paths with original and current values. Kind is ChangeAdded if path was not reachable
+// in original, like index out of range or nil ptr, ChangeRemoved if it is not reachable now.
+func (t *Tracked[T]) Changes() []Change {
+	changes := make([]Change, 0, len(t.dirty))
+
+	for _, path := range t.dirty {
+		var (
+			change    = Change{Path: path, Kind: ChangeModified}
+			old, oErr = t.field(&t.original, path)
+			cur, cErr = t.field(&t.value, path)
+		)
+
+		if oErr == nil {
+			change.Old = old.Interface()
+		} else {
+			change.Kind = ChangeAdded
+		}
+
+		if cErr == nil {
+			change.New = cur.Interface()
+		} else {
+			change.Kind = ChangeRemoved
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// Reset - accept changes: current struct becomes original, no paths are dirty.
+func (t *Tracked[T]) Reset() {
+	t.original = Clone(t.value, t.opts...)
+	t.dirty = nil
+}
+
+// Rollback - discard changes: current struct is restored from original, no paths are dirty.
+func (t *Tracked[T]) Rollback() {
+	t.value = Clone(t.original, t.opts...)
+	t.dirty = nil
+}
+
+// mark - add path to dirty if its value differs from original, remove it otherwise.
+func (t *Tracked[T]) mark(path string) {
+	var (
+		changed = t.changed(path)
+		i       = slices.Index(t.dirty, path)
+	)
+
+	switch {
+	case changed && i < 0:
+		t.dirty = append(t.dirty, path)
+	case !changed && i >= 0:
+		t.dirty = slices.Delete(t.dirty, i, i+1)
+	}
+}
+
+// changed - field by path differs from original, compared like in Diff.
+func (t *Tracked[T]) changed(path string) bool {
+	old, oErr := t.field(&t.original, path)
+	cur, cErr := t.field(&t.value, path)
+	// not reachable path check
+	if oErr != nil || cErr != nil {
+		return oErr == nil || cErr == nil
+	}
+
+	d := &differ{o: t.o}
+	d.diff(path, old, cur)
+
+	return len(d.changes) > 0
+}
+
+// field - field of struct by path.
+func (t *Tracked[T]) field(obj *T, path string) (reflect.Value, error) {
+	return getField(reflect.ValueOf(obj).Elem(), path, t.o)
+}
@@ -0,0 +1,335 @@
+package attrs
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type trackedAddress struct {
+	City string `json:"city"`
+}
+
+type trackedItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+type trackedOrder struct {
+	ID      int             `json:"id"`
+	Status  string          `json:"status"`
+	Total   float64         `json:"total"`
+	Address *trackedAddress `json:"address"`
+	Items   []trackedItem   `json:"items"`
+	Meta    map[string]int  `json:"meta"`
+}
+
+func TestTrackedSet(t *testing.T) {
+	original := trackedOrder{
+		ID:     1,
+		Status: "new",
+		Total:  10.5,
+		Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+		Meta:   map[string]int{"a": 1},
+	}
+
+	testCases := []struct {
+		sets        [][2]interface{}
+		opts        []Option
+		expected    []Change
+		expectedErr error
+		testName    string
+	}{
+		{
+			sets: [][2]interface{}{{"Status", "paid"}, {"Total", 12.5}, {"Items[0].Price", 2.5}},
+			expected: []Change{
+				{Path: "Status", Old: "new", New: "paid", Kind: ChangeModified},
+				{Path: "Total", Old: 10.5, New: 12.5, Kind: ChangeModified},
+				{Path: "Items[0].Price", Old: 1.5, New: 2.5, Kind: ChangeModified},
+			},
+			testName: "OK. Fields and paths",
+		},
+		{
+			sets:     [][2]interface{}{{"Status", "paid"}, {"Total", 12.5}, {"Status", "new"}, {"ID", 1}},
+			expected: []Change{{Path: "Total", Old: 10.5, New: 12.5, Kind: ChangeModified}},
+			testName: "OK. Set back and same values are not dirty",
+		},
+		{
+			sets: [][2]interface{}{{"address.city", "moscow"}, {"meta[\"a\"]", "2"}},
+			opts: []Option{WithTagKey("json"), WithConvert()},
+			expected: []Change{
+				{Path: "address.city", New: "moscow", Kind: ChangeAdded},
+				{Path: "meta[\"a\"]", Old: 1, New: 2, Kind: ChangeModified},
+			},
+			testName: "OK. Options of Track",
+		},
+		{
+			sets:     [][2]interface{}{{"Total", 10.5000001}},
+			opts:     []Option{WithFloatPrecision(2)},
+			expected: []Change{},
+			testName: "OK. Float precision",
+		},
+		{
+			sets:        [][2]interface{}{{"Status", 1}},
+			expected:    []Change{},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Wrong type",
+		},
+		{
+			sets:        [][2]interface{}{{"Unknown", 1}},
+			expected:    []Change{},
+			expectedErr: ErrFieldNotInStruct,
+			testName:    "ERR. Field not in struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Tracked")
+			t.Description("Check method `Set` of `Tracked`")
+			t.WithParameters(allure.NewParameter("sets", testCase.sets))
+
+			tracked, err := Track(trackedOrder{
+				ID:     1,
+				Status: "new",
+				Total:  10.5,
+				Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+				Meta:   map[string]int{"a": 1},
+			}, testCase.opts...)
+			t.Require().NoError(err)
+
+			for _, set := range testCase.sets {
+				err = tracked.Set(set[0].(string), set[1])
+				if testCase.expectedErr != nil {
+					t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Set error: %v", err))
+					continue
+				}
+
+				t.Require().NoError(err)
+			}
+
+			t.Assert().Equal(testCase.expected, tracked.Changes(), "Check Changes")
+
+			dirty := make([]string, 0, len(testCase.expected))
+			for _, change := range testCase.expected {
+				dirty = append(dirty, change.Path)
+			}
+
+			if len(dirty) == 0 {
+				dirty = nil
+			}
+
+			t.Assert().Equal(dirty, tracked.Dirty(), "Check Dirty")
+			t.Assert().Equal(original, tracked.Original(), "Check original not changed")
+		})
+	}
+}
+
+func TestTrackedMerge(t *testing.T) {
+	type update struct {
+		Status  string
+		Total   *float64
+		Address *trackedAddress
+		Items   []trackedItem
+	}
+
+	total := 10.5
+
+	testCases := []struct {
+		update   update
+		opts     []Option
+		expected []string
+		testName string
+	}{
+		{
+			update:   update{Status: "paid", Total: &total, Address: &trackedAddress{City: "moscow"}},
+			opts:     []Option{WithSkipZero()},
+			expected: []string{"Status", "Address"},
+			testName: "OK. Partial update, same values are not dirty",
+		},
+		{
+			update:   update{Status: "new"},
+			expected: []string{"Items[0]"},
+			testName: "OK. Zero values are set, nil ptrs are skipped",
+		},
+		{
+			update:   update{Items: []trackedItem{{SKU: "b"}}},
+			opts:     []Option{WithAppendSlices(), WithSkipZero()},
+			expected: []string{"Items[1]"},
+			testName: "OK. Merge policy",
+		},
+		{
+			update:   update{Status: "paid", Items: []trackedItem{{SKU: "a", Price: 3}}},
+			opts:     []Option{WithSkipZero(), WithFields("Items")},
+			expected: []string{"Items[0].Price"},
+			testName: "OK. Fields policy",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Tracked")
+			t.Description("Check method `Merge` of `Tracked`")
+			t.WithParameters(allure.NewParameter("update", testCase.update))
+
+			tracked, err := Track(trackedOrder{
+				ID:     1,
+				Status: "new",
+				Total:  10.5,
+				Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+				Meta:   map[string]int{"a": 1},
+			})
+			t.Require().NoError(err)
+
+			t.Require().NoError(tracked.Merge(testCase.update, testCase.opts...))
+			t.Assert().Equal(testCase.expected, tracked.Dirty(), "Check Dirty")
+		})
+	}
+
+	runner.Run(t, "ERR. Wrong type", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Tracked")
+		t.Description("Check method `Merge` of `Tracked` with wrong field type")
+
+		type wrong struct {
+			Status string
+			ID     string
+		}
+
+		tracked, err := Track(trackedOrder{
+			ID:     1,
+			Status: "new",
+			Total:  10.5,
+			Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+			Meta:   map[string]int{"a": 1},
+		})
+		t.Require().NoError(err)
+
+		err = tracked.Merge(wrong{Status: "paid", ID: "2"})
+		t.Assert().ErrorIs(err, ErrWrongFieldValueType)
+		t.Assert().Equal([]string{"Status"}, tracked.Dirty(), "Check fields changed before error are dirty")
+	})
+}
+
+func TestTrackedCycle(t *testing.T) {
+	runner.Run(t, "OK. Ptr cycle", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Tracked")
+		t.Description("Check `Tracked` of struct with ptr cycle")
+
+		type node struct {
+			Value float64
+			Next  *node
+		}
+
+		obj := node{Value: 1}
+		obj.Next = &obj
+
+		tracked, err := Track(obj)
+		t.Require().NoError(err)
+
+		t.Require().NoError(tracked.Merge(struct{ Value float64 }{Value: 2}))
+		t.Require().NoError(tracked.Set("Next", tracked.Original().Next))
+		t.Assert().Equal([]string{"Value"}, tracked.Dirty())
+	})
+}
+
+func TestTrackedResetRollback(t *testing.T) {
+	runner.Run(t, "OK. Reset and Rollback", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Tracked")
+		t.Description("Check methods `Reset` and `Rollback` of `Tracked`")
+
+		tracked, err := Track(trackedOrder{
+			ID:     1,
+			Status: "new",
+			Total:  10.5,
+			Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+			Meta:   map[string]int{"a": 1},
+		})
+		t.Require().NoError(err)
+
+		t.Require().NoError(tracked.Set("Status", "paid"))
+		t.Require().NoError(tracked.Set("Meta[\"a\"]", 5))
+		tracked.Reset()
+		t.Assert().Empty(tracked.Dirty())
+		t.Assert().Equal("paid", tracked.Original().Status)
+
+		t.Require().NoError(tracked.Set("Items[0].Price", 7.5))
+		t.Require().NoError(tracked.Set("Address", &trackedAddress{City: "kazan"}))
+		tracked.Rollback()
+		t.Assert().Empty(tracked.Dirty())
+
+		expected := trackedOrder{
+			ID:     1,
+			Status: "paid",
+			Total:  10.5,
+			Items:  []trackedItem{{SKU: "a", Price: 1.5}},
+			Meta:   map[string]int{"a": 5},
+		}
+		t.Assert().Equal(expected, tracked.Value(), "Check Rollback to Reset state")
+
+		// rollback value is a clone of original
+		t.Require().NoError(tracked.Set("Meta[\"a\"]", 6))
+		t.Assert().Equal(5, tracked.Original().Meta["a"])
+	})
+
+	runner.Run(t, "ERR. Not a struct", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Tracked")
+		t.Description("Check func `Track` with not struct")
+
+		_, err := Track(&trackedOrder{})
+		t.Assert().EqualError(err, "Track: not a struct (got *attrs.trackedOrder)")
+	})
+}
+
+func ExampleTrack() {
+	type Order struct {
+		ID     int
+		Status string
+		Total  float64
+	}
+
+	type Update struct {
+		Status string
+		Total  float64
+	}
+
+	tracked, err := Track(Order{ID: 1, Status: "new", Total: 10})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tracked.Set("Status", "paid"); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tracked.Merge(Update{Status: "paid", Total: 12}); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(tracked.Dirty())
+	fmt.Println(tracked.Changes())
+
+	tracked.Rollback()
+	fmt.Println(tracked.Value())
+	// Output:
+	// [Status Total]
+	// [modified Status: new -> paid modified Total: 10 -> 12]
+	// {1 new 10}
+}
@@ -0,0 +1,319 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ruauka/tools-go/conv"
+	"github.com/ruauka/tools-go/rmath"
+)
+
+// validateTagKey - struct tag key of field rules like `validate:"required,min=1"`.
+const validateTagKey = "validate"
+
+// ErrValidation - field value violates validate rule, wrapped by every ValidationError.
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError - violation of one validate rule by field value.
+type ValidationError struct {
+	Path  string      // field path like `Items[2].Price`
+	Rule  string      // rule name like `min`
+	Param string      // rule param like `0`, empty for rules without param
+	Value interface{} // field value
+}
+
+// Error - violation message like `Items[2].Price: min=0: got -1`.
+func (e *ValidationError) Error() string {
+	rule := e.Rule
+	if e.Param != "" {
+		rule += "=" + e.Param
+	}
+
+	return fmt.Sprintf("%s: %s: got %v", e.Path, rule, e.Value)
+}
+
+// Unwrap - ErrValidation for errors.Is.
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// ValidationErrors - all violations found by Validate, in struct order.
+type ValidationErrors []*ValidationError
+
+// Error - violations joined by `; `.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap - violations for errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// ValidatorFunc - custom validate rule: reports if field value is valid by rule param.
+// Value is dereferenced, nil ptrs are not passed.
+type ValidatorFunc func(value interface{}, param string) bool
+
+var (
+	// validators - custom validate rules by name, registered by RegisterValidator.
+	validators sync.Map
+	// regexps - cache of compiled regexp rule params.
+	regexps sync.Map
+)
+
+// RegisterValidator - add custom validate rule used as `validate:"name"` or `validate:"name=param"`.
+// Built-in rules can not be replaced.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validators.Store(name, fn)
+}
+
+// Validate - check struct fields by tag `validate:"..."` with comma-separated rules:
+// required, min=N, max=N, len=N, oneof=a b c, regexp=expr (the last rule, may contain commas) and custom rules.
+// min, max and len compare numbers by value, strings by rune count, slices and maps by length.
+// Floats are compared after rounding by field tag `round` or WithFloatPrecision.
+// Walks into nested structs, ptrs, slices and maps like Walk. Returns all violations as ValidationErrors.
+// 'obj': struct or ptr struct.
+// 'opts': optional, WithTagKey for tag names in paths, WithFloatPrecision.
+func Validate(obj interface{}, opts ...Option) error {
+	var (
+		o    = newOptions(opts)
+		errs ValidationErrors
+	)
+
+	visitor := func(field *WalkField) error {
+		tag, ok := field.StructField.Tag.Lookup(validateTagKey)
+		if !ok || tag == "" {
+			return nil
+		}
+
+		violations, err := validateField(field, tag, o)
+		if err != nil {
+			return fieldError(opValidate, field.Path, err)
+		}
+
+		errs = append(errs, violations...)
+
+		return nil
+	}
+
+	if err := Walk(obj, visitor, opts...); err != nil {
+		return opError(opValidate, err)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateField - violations of tag rules by field value. Error for invalid rules.
+func validateField(field *WalkField, tag string, o *options) ([]*ValidationError, error) {
+	var (
+		v          = field.Value
+		violations []*ValidationError
+	)
+	// nil ptr is checked by required only
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+
+	for tag != "" {
+		var rule string
+		// regexp param is the rest of tag
+		if strings.HasPrefix(tag, "regexp=") {
+			rule, tag = tag, ""
+		} else {
+			rule, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		// required checks field itself: nil ptr, empty string, slice or map, zero value, zero array
+		ok := !isEmpty(field.Value)
+		if name != "required" {
+			var err error
+			if ok, err = checkRule(v, field.StructField.Tag, name, param, o); err != nil {
+				return nil, err
+			}
+		}
+
+		if !ok {
+			violations = append(violations, &ValidationError{
+				Path:  field.Path,
+				Rule:  name,
+				Param: param,
+				Value: valueInterface(v),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// checkRule - reports if value satisfies rule. Nil values pass.
+func checkRule(v reflect.Value, tag reflect.StructTag, name, param string, o *options) (bool, error) {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return true, nil
+	}
+
+	switch name {
+	case "min", "max", "len":
+		limit, err := conv.StringToFloat64(param)
+		if err != nil {
+			return false, fmt.Errorf("%w: %s param %q", ErrInvalidTag, name, param)
+		}
+
+		value, err := measure(v, tag, &limit, o)
+		if err != nil {
+			return false, err
+		}
+
+		switch name {
+		case "min":
+			return value >= limit, nil
+		case "max":
+			return value <= limit, nil
+		default:
+			return value == limit, nil
+		}
+	case "oneof":
+		for _, option := range strings.Fields(param) {
+			ok, err := equalParam(v, tag, option, o)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case "regexp":
+		if v.Kind() != reflect.String {
+			return false, typeError(ErrWrongFieldValueType, reflect.TypeOf(""), v.Type())
+		}
+
+		re, err := compileRegexp(param)
+		if err != nil {
+			return false, err
+		}
+
+		return re.MatchString(v.String()), nil
+	default:
+		fn, ok := validators.Load(name)
+		if !ok {
+			return false, fmt.Errorf("%w: unknown validate rule %q", ErrInvalidTag, name)
+		}
+
+		return fn.(ValidatorFunc)(valueInterface(v), param), nil
+	}
+}
+
+// measure - value compared by min, max and len: number, rune count of string, length of slice or map.
+// Floats and limit are rounded by field round spec.
+func measure(v reflect.Value, tag reflect.StructTag, limit *float64, o *options) (float64, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		spec, ok, err := floatSpec(tag, o)
+		if err != nil || !ok {
+			return v.Float(), err
+		}
+
+		*limit = spec.round(*limit, spec.precision)
+
+		return spec.round(v.Float(), spec.precision), nil
+	default:
+		return 0, typeError(ErrWrongFieldValueType, reflect.TypeOf(float64(0)), v.Type())
+	}
+}
+
+// equalParam - reports if value equals oneof option: numbers by value, others by string form.
+func equalParam(v reflect.Value, tag reflect.StructTag, option string, o *options) (bool, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		limit, err := conv.StringToFloat64(option)
+		if err != nil {
+			return false, fmt.Errorf("%w: oneof param %q", ErrInvalidTag, option)
+		}
+
+		value, err := measure(v, tag, &limit, o)
+
+		return value == limit, err
+	default:
+		return fmt.Sprint(v.Interface()) == option, nil
+	}
+}
+
+// floatSpec - rounding of float field before comparison: field tag `round`, else WithFloatPrecision.
+func floatSpec(tag reflect.StructTag, o *options) (roundSpec, bool, error) {
+	parent := roundSpec{precision: o.floatPrec, round: rmath.Round[float64]}
+	if _, ok := tag.Lookup(roundTagKey); !ok {
+		return parent, o.hasFloatPrec, nil
+	}
+
+	return parseRoundTag(tag, parent)
+}
+
+// isEmpty - nil ptr or interface, empty string, slice or map, zero value of other types like arrays and structs.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// valueInterface - value for ValidationError, nil for invalid value.
+func valueInterface(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// compileRegexp - cached compiled regexp rule param.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexps.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: regexp param: %v", ErrInvalidTag, err)
+	}
+
+	regexps.Store(expr, re)
+
+	return re, nil
+}
@@ -0,0 +1,264 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type validateItem struct {
+	Name  string  `json:"name" validate:"required,len=3"`
+	Price float64 `json:"price" validate:"min=0,max=100"`
+}
+
+type validateOrder struct {
+	Code     string            `json:"code" validate:"regexp=^[A-Z]{2,3}$"`
+	Status   string            `json:"status" validate:"oneof=new paid"`
+	Priority int               `json:"priority" validate:"oneof=1 2 3"`
+	Items    []validateItem    `json:"items" validate:"min=1"`
+	Main     *validateItem     `json:"main" validate:"required"`
+	Extra    *validateItem     `json:"extra"`
+	Meta     map[string]string `json:"meta" validate:"max=1"`
+	Discount float64           `json:"discount" round:"2" validate:"max=0.5"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateOrder{
+		Code:     "AB",
+		Status:   "new",
+		Priority: 2,
+		Items:    []validateItem{{Name: "abc", Price: 100}},
+		Main:     &validateItem{Name: "ёжи", Price: 0},
+		Discount: 0.504,
+	}
+
+	testCases := []struct {
+		obj      interface{}
+		opts     []Option
+		expected []string
+		testName string
+	}{
+		{
+			obj:      valid,
+			testName: "OK. Valid struct",
+		},
+		{
+			obj:      &valid,
+			testName: "OK. Valid ptr struct",
+		},
+		{
+			obj: validateOrder{
+				Code:     "abc",
+				Status:   "done",
+				Priority: 4,
+				Items:    []validateItem{{Name: "ab", Price: -1}, {Price: 100.5}},
+				Extra:    &validateItem{Name: "abc", Price: 1},
+				Meta:     map[string]string{"a": "a", "b": "b"},
+				Discount: 0.505,
+			},
+			expected: []string{
+				"Code: regexp=^[A-Z]{2,3}$: got abc",
+				"Status: oneof=new paid: got done",
+				"Priority: oneof=1 2 3: got 4",
+				"Items[0].Name: len=3: got ab",
+				"Items[0].Price: min=0: got -1",
+				"Items[1].Name: required: got ",
+				"Items[1].Name: len=3: got ",
+				"Items[1].Price: max=100: got 100.5",
+				"Main: required: got <nil>",
+				"Meta: max=1: got map[a:a b:b]",
+				"Discount: max=0.5: got 0.505",
+			},
+			testName: "ERR. All violations",
+		},
+		{
+			obj:  validateOrder{Items: []validateItem{}},
+			opts: []Option{WithTagKey("json")},
+			expected: []string{
+				"code: regexp=^[A-Z]{2,3}$: got ",
+				"status: oneof=new paid: got ",
+				"priority: oneof=1 2 3: got 0",
+				"items: min=1: got []",
+				"main: required: got <nil>",
+			},
+			testName: "ERR. Tag names",
+		},
+		{
+			obj: struct {
+				Rate float64 `validate:"max=1"`
+			}{Rate: 1.004},
+			opts:     []Option{WithFloatPrecision(2)},
+			testName: "OK. Float precision option",
+		},
+		{
+			obj: struct {
+				Rate float64 `validate:"max=1"`
+			}{Rate: 1.004},
+			expected: []string{"Rate: max=1: got 1.004"},
+			testName: "ERR. Float without precision",
+		},
+		{
+			obj: struct {
+				ID   [4]byte `validate:"required"`
+				Zone struct {
+					Code string
+				} `validate:"required"`
+			}{},
+			expected: []string{"ID: required: got [0 0 0 0]", "Zone: required: got {}"},
+			testName: "ERR. Required zero array and struct",
+		},
+		{
+			obj: struct {
+				ID [4]byte `validate:"required"`
+			}{ID: [4]byte{0, 0, 0, 1}},
+			testName: "OK. Required not zero array",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Validate")
+			t.Description("Check func `Validate`")
+			t.WithParameters(allure.NewParameter("obj", testCase.obj))
+
+			err := Validate(testCase.obj, testCase.opts...)
+			if testCase.expected == nil {
+				t.Assert().NoError(err)
+				return
+			}
+
+			var errs ValidationErrors
+			t.Require().True(errors.As(err, &errs), fmt.Sprintf("Validate error: %v", err))
+			t.Assert().ErrorIs(err, ErrValidation)
+
+			actual := make([]string, len(errs))
+			for i, e := range errs {
+				actual[i] = e.Error()
+			}
+
+			t.Assert().Equal(testCase.expected, actual, "Check violations")
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	testCases := []struct {
+		obj         interface{}
+		expectedErr error
+		testName    string
+	}{
+		{
+			obj: struct {
+				Name string `validate:"unknown"`
+			}{},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Unknown rule",
+		},
+		{
+			obj: struct {
+				Age int `validate:"min=a"`
+			}{},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Invalid param",
+		},
+		{
+			obj: struct {
+				Name string `validate:"regexp=["`
+			}{Name: "a"},
+			expectedErr: ErrInvalidTag,
+			testName:    "ERR. Invalid regexp",
+		},
+		{
+			obj: struct {
+				Age int `validate:"regexp=^1$"`
+			}{Age: 1},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Regexp on int",
+		},
+		{
+			obj: struct {
+				Ok bool `validate:"min=1"`
+			}{},
+			expectedErr: ErrWrongFieldValueType,
+			testName:    "ERR. Min on bool",
+		},
+		{
+			obj:         1,
+			expectedErr: ErrNotStruct,
+			testName:    "ERR. Not a struct",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Validate")
+			t.Description("Check func `Validate` errors")
+
+			err := Validate(testCase.obj)
+			t.Assert().ErrorIs(err, testCase.expectedErr, fmt.Sprintf("Validate error: %v", err))
+			t.Assert().False(errors.Is(err, ErrValidation), "Check not a violation")
+		})
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	runner.Run(t, "OK. Custom validator", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("RegisterValidator")
+		t.Description("Check func `RegisterValidator`")
+
+		RegisterValidator("prefix", func(value interface{}, param string) bool {
+			s, ok := value.(string)
+			return ok && strings.HasPrefix(s, param)
+		})
+		defer validators.Delete("prefix")
+
+		type User struct {
+			Login *string `validate:"prefix=usr_"`
+		}
+
+		login := "admin"
+		t.Assert().NoError(Validate(User{}), "Check nil ptr skipped")
+		t.Assert().EqualError(Validate(User{Login: &login}), "Login: prefix=usr_: got admin")
+	})
+}
+
+func ExampleValidate() {
+	type Item struct {
+		Name  string  `validate:"required"`
+		Price float64 `round:"2" validate:"min=0.01"`
+	}
+
+	type Order struct {
+		Code  string `validate:"regexp=^[A-Z]+$"`
+		Items []Item `validate:"min=1,max=10"`
+	}
+
+	err := Validate(Order{Code: "a1", Items: []Item{{Price: 0.006}, {Name: "b", Price: 0.004}}})
+
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			fmt.Println(e.Path, e.Rule)
+		}
+	}
+	// Output:
+	// Code regexp
+	// Items[0].Name required
+	// Items[1].Price min
+}
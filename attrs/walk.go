@@ -0,0 +1,223 @@
+package attrs
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Visitor control errors, returned by Visitor like fs.SkipDir.
+var (
+	SkipField = errors.New("skip this field") // do not walk into fields of visited field
+	SkipAll   = errors.New("skip all fields") // stop walk, Walk returns nil
+)
+
+// WalkField - struct field visited by Walk.
+type WalkField struct {
+	Path        string              // field path like `Items[2].Price`
+	StructField reflect.StructField // field of struct type
+	Value       reflect.Value       // field value, settable if obj passed by ptr
+	Depth       int                 // nesting level of struct, 0 for obj fields
+	o           *options
+}
+
+// Set - replace field value like SetAttr, then Walk goes into the new value.
+// 'value': new field value, for ptr field value or ptr.
+func (f *WalkField) Set(value interface{}) error {
+	if !f.Value.CanSet() {
+		return fieldError(opWalk, f.Path, ErrNotPointerStruct)
+	}
+
+	if err := assign(f.Value, reflect.ValueOf(value), f.o); err != nil {
+		return fieldError(opWalk, f.Path, err)
+	}
+
+	return nil
+}
+
+// Visitor - func called by Walk for every field. Returns SkipField to not walk into the field,
+// SkipAll to stop walk, other errors stop walk and are returned by Walk.
+type Visitor func(field *WalkField) error
+
+// Walk - visit exported struct fields recursively in struct order, parent before nested fields.
+// Goes into nested structs, ptrs, interfaces, slices, arrays and maps (keys in sorted order), nil ptrs
+// and cycles are skipped.
+// 'obj': struct or ptr struct, ptr to replace values.
+// 'visitor': func called for every field.
+// 'opts': optional, WithTagKey for tag names in paths, WithConvert for WalkField.Set.
+func Walk(obj interface{}, visitor Visitor, opts ...Option) error {
+	var (
+		objValue = reflect.ValueOf(obj)
+		w        = &walker{visit: visitor, o: newOptions(opts)}
+	)
+	// ptr struct check, root ptr is visited for cycles
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() {
+		w.visited = map[ptrKey]bool{{addr: objValue.Pointer(), typ: objValue.Type()}: true}
+		objValue = objValue.Elem()
+	}
+	// is struct check
+	if objValue.Kind() != reflect.Struct {
+		return objError(opWalk, ErrNotStruct, objValue)
+	}
+
+	err := w.walkStruct(objValue, "", 0)
+	if errors.Is(err, SkipAll) {
+		return nil
+	}
+
+	return err
+}
+
+// Fields - iterator over struct fields visited by Walk, for `for field := range attrs.Fields(obj)` loops.
+// Nothing is yielded if obj is not a struct or ptr struct.
+func Fields(obj interface{}, opts ...Option) func(yield func(*WalkField) bool) {
+	return func(yield func(*WalkField) bool) {
+		visitor := func(field *WalkField) error {
+			if !yield(field) {
+				return SkipAll
+			}
+
+			return nil
+		}
+		// not a struct: nothing to yield
+		if err := Walk(obj, visitor, opts...); err != nil {
+			return
+		}
+	}
+}
+
+// walker - walks struct fields calling visitor.
+type walker struct {
+	visit   Visitor
+	o       *options
+	visited map[ptrKey]bool // pointers already walked, protects from cycles
+}
+
+// walkStruct - visit struct value fields and walk into them.
+func (w *walker) walkStruct(v reflect.Value, path string, depth int) error {
+	fields := structFields(v.Type(), w.o)
+	for i := range fields {
+		f := &fields[i]
+		// unexported fields are skipped
+		if !f.exported {
+			continue
+		}
+		// is embedded ptr nil check
+		field, err := fieldByInfo(v, f, false)
+		if err != nil {
+			continue
+		}
+
+		visited := &WalkField{
+			Path:        joinName(path, f.name),
+			StructField: v.Type().FieldByIndex(f.index),
+			Value:       field,
+			Depth:       depth,
+			o:           w.o,
+		}
+
+		if err := w.visit(visited); err != nil {
+			if errors.Is(err, SkipField) {
+				continue
+			}
+
+			return err
+		}
+
+		if err := w.walk(field, visited.Path, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walk - walk into value: struct, ptr, interface, slice, array or map of them.
+func (w *walker) walk(v reflect.Value, path string, depth int) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		// nil ptr and cycle check
+		if v.IsNil() {
+			return nil
+		}
+
+		key := ptrKey{addr: v.Pointer(), typ: v.Type()}
+		if w.visited[key] {
+			return nil
+		}
+
+		if w.visited == nil {
+			w.visited = make(map[ptrKey]bool)
+		}
+		w.visited[key] = true
+
+		return w.walk(v.Elem(), path, depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// interface elem is not addressable: walk a copy and put it back
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr || !mayHaveFields(elem.Type()) {
+			return w.walk(elem, path, depth)
+		}
+
+		walked := reflect.New(elem.Type()).Elem()
+		walked.Set(elem)
+
+		if err := w.walk(walked, path, depth); err != nil {
+			return err
+		}
+
+		if v.CanSet() {
+			v.Set(walked)
+		}
+	case reflect.Struct:
+		return w.walkStruct(v, path, depth)
+	case reflect.Slice, reflect.Array:
+		if !mayHaveFields(v.Type().Elem()) {
+			return nil
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), indexPath(path, i), depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Len() == 0 || !mayHaveFields(v.Type().Elem()) {
+			return nil
+		}
+
+		keys := v.MapKeys()
+		sortKeys(keys)
+		// map elements are not addressable: walk a copy and put it back
+		walked := reflect.New(v.Type().Elem()).Elem()
+
+		for _, key := range keys {
+			walked.Set(v.MapIndex(key))
+
+			if err := w.walk(walked, path+keySegment(key).String(), depth); err != nil {
+				return err
+			}
+
+			if v.CanSet() {
+				v.SetMapIndex(key, walked)
+			}
+		}
+	default:
+	}
+
+	return nil
+}
+
+// mayHaveFields - false for types which can not contain struct fields, so they are not walked.
+func mayHaveFields(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return mayHaveFields(t.Elem())
+	default:
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package attrs
+
+import "fmt"
+
+func ExampleFields() {
+	type Address struct {
+		City string
+	}
+
+	type User struct {
+		Name    string
+		Address Address
+	}
+
+	for field := range Fields(User{Name: "name", Address: Address{City: "city"}}) {
+		fmt.Println(field.Path, field.Value)
+	}
+	// Output:
+	// Name name
+	// Address {city}
+	// Address.City city
+}
@@ -0,0 +1,259 @@
+package attrs
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+type walkItem struct {
+	Name  string `json:"name"`
+	Price float64
+}
+
+type walkOrder struct {
+	ID      int                 `json:"id"`
+	Items   []walkItem          `json:"items"`
+	ByKey   map[string]walkItem `json:"by_key"`
+	Main    *walkItem           `json:"main"`
+	Nil     *walkItem           `json:"nil"`
+	Any     interface{}         `json:"any"`
+	Parent  *walkOrder          `json:"parent"`
+	Comment string              `json:"comment"`
+	secret  string
+}
+
+func TestWalk(t *testing.T) {
+	order := &walkOrder{
+		ID:      1,
+		Items:   []walkItem{{Name: "a", Price: 1}, {Name: "b", Price: 2}},
+		ByKey:   map[string]walkItem{"y": {Name: "y"}, "x": {Name: "x"}},
+		Main:    &walkItem{Name: "main"},
+		Any:     walkItem{Name: "any"},
+		Comment: "comment",
+		secret:  "secret",
+	}
+	order.Parent = order
+
+	allPaths := []string{
+		"ID",
+		"Items", "Items[0].Name", "Items[0].Price", "Items[1].Name", "Items[1].Price",
+		"ByKey", `ByKey["x"].Name`, `ByKey["x"].Price`, `ByKey["y"].Name`, `ByKey["y"].Price`,
+		"Main", "Main.Name", "Main.Price",
+		"Nil",
+		"Any", "Any.Name", "Any.Price",
+		"Parent",
+		"Comment",
+	}
+
+	testCases := []struct {
+		visitor  func(paths *[]string) Visitor
+		opts     []Option
+		expected []string
+		testName string
+	}{
+		{
+			visitor: func(paths *[]string) Visitor {
+				return func(field *WalkField) error {
+					*paths = append(*paths, field.Path)
+					return nil
+				}
+			},
+			expected: allPaths,
+			testName: "OK. All fields, cycle and unexported skipped",
+		},
+		{
+			visitor: func(paths *[]string) Visitor {
+				return func(field *WalkField) error {
+					*paths = append(*paths, field.Path)
+					if field.Value.Kind() == reflect.Slice || field.Value.Kind() == reflect.Map {
+						return SkipField
+					}
+
+					return nil
+				}
+			},
+			expected: []string{
+				"ID", "Items", "ByKey", "Main", "Main.Name", "Main.Price", "Nil", "Any", "Any.Name", "Any.Price",
+				"Parent", "Comment",
+			},
+			testName: "OK. Skip subtrees",
+		},
+		{
+			visitor: func(paths *[]string) Visitor {
+				return func(field *WalkField) error {
+					*paths = append(*paths, field.Path)
+					if field.Path == "Items[0].Name" {
+						return SkipAll
+					}
+
+					return nil
+				}
+			},
+			expected: []string{"ID", "Items", "Items[0].Name"},
+			testName: "OK. Stop early",
+		},
+		{
+			visitor: func(paths *[]string) Visitor {
+				return func(field *WalkField) error {
+					if field.Depth == 1 {
+						*paths = append(*paths, field.Path)
+					}
+
+					return nil
+				}
+			},
+			opts: []Option{WithTagKey("json")},
+			expected: []string{
+				"items[0].name", "items[0].Price", "items[1].name", "items[1].Price",
+				`by_key["x"].name`, `by_key["x"].Price`, `by_key["y"].name`, `by_key["y"].Price`,
+				"main.name", "main.Price", "any.name", "any.Price",
+			},
+			testName: "OK. Nested fields by depth and tag names",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrs")
+			t.Story("Walk")
+			t.Description("Check func `Walk`")
+			t.WithParameters(
+				allure.NewParameter("expected", testCase.expected),
+			)
+
+			var actual []string
+
+			err := Walk(order, testCase.visitor(&actual), testCase.opts...)
+			t.Require().NoError(err, "Walk error")
+			t.Assert().Equal(testCase.expected, actual, "Check Walk")
+		})
+	}
+}
+
+func TestWalkSet(t *testing.T) {
+	runner.Run(t, "OK. Replace values", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Walk")
+		t.Description("Check `WalkField.Set` in visitor")
+
+		order := &walkOrder{
+			Items: []walkItem{{Name: "a", Price: 1}, {Name: "b", Price: 2}},
+			ByKey: map[string]walkItem{"y": {Name: "y"}, "x": {Name: "x"}},
+			Main:  &walkItem{Name: "main"},
+			Any:   walkItem{Name: "any"},
+		}
+
+		err := Walk(order, func(field *WalkField) error {
+			if field.StructField.Name == "Name" {
+				return field.Set(strings.ToUpper(field.Value.String()))
+			}
+
+			if field.Path == "Nil" {
+				return field.Set(walkItem{Name: "new"})
+			}
+
+			return nil
+		})
+		t.Require().NoError(err, "Walk error")
+
+		t.Assert().Equal([]walkItem{{Name: "A", Price: 1}, {Name: "B", Price: 2}}, order.Items)
+		t.Assert().Equal(map[string]walkItem{"y": {Name: "Y"}, "x": {Name: "X"}}, order.ByKey)
+		t.Assert().Equal(&walkItem{Name: "MAIN"}, order.Main)
+		t.Assert().Equal(&walkItem{Name: "NEW"}, order.Nil, "Check walk into new value")
+		t.Assert().Equal(walkItem{Name: "ANY"}, order.Any)
+	})
+
+	runner.Run(t, "ERR. Errors", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Walk")
+		t.Description("Check `Walk` errors")
+
+		err := Walk(walkOrder{ID: 1}, func(field *WalkField) error {
+			return field.Set(2)
+		})
+		t.Assert().ErrorIs(err, ErrNotPointerStruct)
+
+		err = Walk(&walkOrder{ID: 1}, func(field *WalkField) error {
+			return field.Set("2")
+		})
+		t.Assert().ErrorIs(err, ErrWrongFieldValueType)
+		t.Assert().EqualError(err, "Walk ID: wrong field value type (expected int, got string)")
+
+		errVisitor := errors.New("visitor error")
+		err = Walk(&walkOrder{ID: 1}, func(*WalkField) error {
+			return errVisitor
+		})
+		t.Assert().ErrorIs(err, errVisitor)
+
+		err = Walk(1, func(*WalkField) error { return nil })
+		t.Assert().ErrorIs(err, ErrNotStruct)
+	})
+}
+
+func TestFields(t *testing.T) {
+	runner.Run(t, "OK. Iterate and break", func(t provider.T) {
+		t.Epic("attrs")
+		t.Story("Fields")
+		t.Description("Check func `Fields`")
+
+		var paths []string
+
+		Fields(&walkOrder{ID: 1, Items: []walkItem{{Name: "a"}}})(func(field *WalkField) bool {
+			paths = append(paths, field.Path)
+			return field.Path != "Items"
+		})
+		t.Assert().Equal([]string{"ID", "Items"}, paths)
+
+		Fields(1)(func(*WalkField) bool {
+			t.Errorf("yield called for not a struct")
+			return true
+		})
+	})
+}
+
+func ExampleWalk() {
+	type Item struct {
+		Name  string
+		Price float64
+	}
+
+	type Order struct {
+		ID    int
+		Items []Item
+	}
+
+	order := &Order{ID: 1, Items: []Item{{Name: "a", Price: 1.5}}}
+
+	err := Walk(order, func(field *WalkField) error {
+		fmt.Println(field.Depth, field.Path, field.StructField.Type)
+
+		if field.Path == "Items[0].Price" {
+			return field.Set(2.5)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(order.Items)
+	// Output:
+	// 0 ID int
+	// 0 Items []attrs.Item
+	// 1 Items[0].Name string
+	// 1 Items[0].Price float64
+	// [{a 2.5}]
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// field - struct field visible by name, promoted fields are reached through embedded fields.
+type field struct {
+	v     *types.Var   // field
+	path  []*types.Var // embedded fields before the field
+	depth int          // embedding depth
+}
+
+// expr - field expression of receiver.
+func (f *field) expr(recv string) string {
+	var b strings.Builder
+
+	b.WriteString(recv)
+
+	for _, v := range f.path {
+		b.WriteString("." + v.Name())
+	}
+
+	b.WriteString("." + f.v.Name())
+
+	return b.String()
+}
+
+// visibleFields - fields visible by names like reflect.VisibleFields: fields hidden by other fields
+// and ambiguous fields of the same depth are dropped.
+func (g *generator) visibleFields(st *types.Struct) ([]*field, error) {
+	var (
+		all    = collectFields(st, nil, make(map[*types.Named]bool))
+		depths = make(map[string]int) // the least depth by name
+		counts = make(map[string]int) // number of fields of the least depth by name
+	)
+
+	for _, f := range all {
+		depth, ok := depths[f.v.Name()]
+		switch {
+		case !ok || f.depth < depth:
+			depths[f.v.Name()], counts[f.v.Name()] = f.depth, 1
+		case f.depth == depth:
+			counts[f.v.Name()]++
+		}
+	}
+
+	fields := make([]*field, 0, len(all))
+
+	for _, f := range all {
+		if f.v.Name() == "_" || f.depth != depths[f.v.Name()] || counts[f.v.Name()] > 1 {
+			continue
+		}
+		// embedded fields of other packages check
+		for _, v := range f.path {
+			if !v.Exported() && v.Pkg() != g.pkg {
+				return nil, fmt.Errorf("%w: field %s through unexported embedded %s", errUnsupported, f.v.Name(), v.Name())
+			}
+		}
+
+		if f.v.Exported() && !g.accessible(f.v.Type()) {
+			return nil, fmt.Errorf("%w: field %s of unexported type", errUnsupported, f.v.Name())
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// collectFields - struct fields with fields of embedded structs after them.
+func collectFields(st *types.Struct, path []*types.Var, visited map[*types.Named]bool) []*field {
+	var fields []*field
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		fields = append(fields, &field{v: v, path: path, depth: len(path)})
+		// embedded struct check
+		t := v.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+
+		named, ok := t.(*types.Named)
+		if !v.Embedded() || !ok || visited[named] {
+			continue
+		}
+
+		if est, ok := named.Underlying().(*types.Struct); ok {
+			visited[named] = true
+			fields = append(fields, collectFields(est, append(path[:len(path):len(path)], v), visited)...)
+			delete(visited, named)
+		}
+	}
+
+	return fields
+}
+
+// genGetAttr - generate GetAttr like attrs.GetAttr for field names.
+func (g *generator) genGetAttr(obj *types.TypeName, recv string, fields []*field) {
+	attrs := g.attrs()
+
+	g.printf("\n// GetAttr - get field value by name without reflection, used by attrs.GetAttr.\n")
+	g.printf("func (%s %s) GetAttr(name string) (interface{}, error) {\nswitch name {\n", recv, obj.Name())
+
+	for _, f := range fields {
+		g.printf("case %q:\n", f.v.Name())
+		// is field exported
+		if !f.v.Exported() {
+			g.printf("return nil, attrsgenError(%q, name, %s.ErrUnexportedField)\n", "GetAttr", attrs)
+			continue
+		}
+		// embedded nil ptrs check
+		for i, v := range f.path {
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+				prefix := (&field{v: v, path: f.path[:i]}).expr(recv)
+				g.printf("if %s == nil {\nreturn nil, attrsgenError(%q, name, %s.ErrNilPointer)\n}\n\n", prefix, "GetAttr", attrs)
+			}
+		}
+
+		expr := f.expr(recv)
+		// field ptr check
+		if _, ok := f.v.Type().Underlying().(*types.Pointer); ok {
+			g.printf("if %s == nil {\nreturn nil, nil\n}\n\nreturn *%s, nil\n", expr, expr)
+			continue
+		}
+
+		g.printf("return %s, nil\n", expr)
+	}
+
+	g.printf("default:\nreturn nil, attrsgenError(%q, name, %s.ErrFieldNotInStruct)\n}\n}\n", "GetAttr", attrs)
+}
+
+// genSetAttr - generate SetAttr like attrs.SetAttr for field names: value must be of field type,
+// ptr field gets value by its elem.
+func (g *generator) genSetAttr(obj *types.TypeName, recv string, fields []*field) {
+	attrs := g.attrs()
+
+	g.printf("\n// SetAttr - set field value by name without reflection, used by attrs.SetAttr.\n")
+	g.printf("func (%s *%s) SetAttr(name string, value interface{}) error {\nswitch name {\n", recv, obj.Name())
+
+	for _, f := range fields {
+		g.printf("case %q:\n", f.v.Name())
+		// is field exported
+		if !f.v.Exported() {
+			g.printf("return attrsgenError(%q, name, %s.ErrUnexportedField)\n", "SetAttr", attrs)
+			continue
+		}
+		// embedded nil ptrs are allocated
+		for i, v := range f.path {
+			p, ok := v.Type().Underlying().(*types.Pointer)
+			if !ok {
+				continue
+			}
+
+			prefix := (&field{v: v, path: f.path[:i]}).expr(recv)
+			if !v.Exported() {
+				g.printf("if %s == nil {\nreturn attrsgenError(%q, name, %s.ErrUnexportedField)\n}\n\n", prefix, "SetAttr", attrs)
+				continue
+			}
+
+			g.printf("if %s == nil {\n%s = new(%s)\n}\n\n", prefix, prefix, g.typeString(p.Elem()))
+		}
+
+		g.genSetField(f.expr(recv), f.v.Type())
+	}
+
+	g.printf("default:\nreturn attrsgenError(%q, name, %s.ErrFieldNotInStruct)\n}\n\nreturn nil\n}\n", "SetAttr", attrs)
+}
+
+// genSetField - set value on field expression of type t.
+func (g *generator) genSetField(expr string, t types.Type) {
+	typ := g.typeString(t)
+
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		// value type is never interface type
+		g.printf("return attrsgenTypeError[%s](name, value)\n", typ)
+	case *types.Pointer:
+		g.printf("switch v := value.(type) {\ncase %s:\n%s = v\n", typ, expr)
+		// interface elem matches any value, so only ptr is set
+		if _, ok := u.Elem().Underlying().(*types.Interface); !ok {
+			g.printf("case %s:\nif %s == nil {\n%s = new(%s)\n}\n*%s = v\n", g.typeString(u.Elem()), expr, expr,
+				g.typeString(u.Elem()), expr)
+		}
+
+		g.printf("default:\nreturn attrsgenTypeError[%s](name, value)\n}\n", typ)
+	default:
+		g.printf("v, ok := value.(%s)\nif !ok {\nreturn attrsgenTypeError[%s](name, value)\n}\n\n%s = v\n", typ, typ, expr)
+	}
+}
+
+// genToMap - generate ToMap like attrs.ToMap: ptrs are dereferenced, nested structs are nested maps.
+func (g *generator) genToMap(obj *types.TypeName, recv string, st *types.Struct) {
+	var exported int
+
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Exported() {
+			exported++
+		}
+	}
+
+	g.printf("\n// ToMap - exported fields to map without reflection, used by attrs.ToMap.\n")
+	g.printf("func (%s %s) ToMap() (map[string]interface{}, error) {\n", recv, obj.Name())
+	g.printf("m := make(map[string]interface{}, %d)\n", exported)
+
+	if hasNestedMaps(st) {
+		g.printf("\nvar err error\n")
+	}
+
+	g.printf("\n")
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+
+		expr := recv + "." + v.Name()
+		// field ptr check
+		if p, ok := v.Type().Underlying().(*types.Pointer); ok {
+			g.printf("if %s == nil {\nm[%q] = nil\n} else ", expr, v.Name())
+
+			if value, ok := g.nestedMap("*"+expr, p.Elem()); ok {
+				g.printf("if m[%q], err = %s; err != nil {\nreturn nil, err\n}\n\n", v.Name(), value)
+				continue
+			}
+
+			g.printf("{\nm[%q] = *%s\n}\n\n", v.Name(), expr)
+
+			continue
+		}
+
+		if value, ok := g.nestedMap(expr, v.Type()); ok {
+			g.printf("if m[%q], err = %s; err != nil {\nreturn nil, err\n}\n\n", v.Name(), value)
+			continue
+		}
+
+		g.printf("m[%q] = %s\n", v.Name(), expr)
+	}
+
+	g.printf("\nreturn m, nil\n}\n")
+}
+
+// nestedMap - map expression of nested struct with exported fields: its generated ToMap or attrs.ToMap.
+// False for other values, they are put to map as is.
+func (g *generator) nestedMap(expr string, t types.Type) (string, bool) {
+	if !isNestedMap(t) {
+		return "", false
+	}
+	// generated struct
+	if named, ok := t.(*types.Named); ok && g.structs[named.Obj()] {
+		return strings.TrimPrefix(expr, "*") + ".ToMap()", true
+	}
+
+	return g.attrs() + ".ToMap(" + expr + ")", true
+}
+
+// isNestedMap - type is struct with exported fields, it is nested map in ToMap.
+func isNestedMap(t types.Type) bool {
+	st, ok := t.Underlying().(*types.Struct)
+	return ok && hasExported(st)
+}
+
+// hasNestedMaps - struct has exported fields or field ptrs of nested struct types with exported fields.
+func hasNestedMaps(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+
+		t := v.Type()
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+
+		if isNestedMap(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasExported - struct has exported fields.
+func hasExported(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Exported() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// directive - comment of struct to generate methods for.
+const directive = "//attrs:generate"
+
+// import paths of generated code.
+const (
+	attrsPath = "github.com/ruauka/tools-go/attrs"
+	rmathPath = "github.com/ruauka/tools-go/rmath"
+)
+
+// errUnsupported - method can not be generated for struct, attrs funcs use reflection for it.
+var errUnsupported = errors.New("not supported")
+
+// generatedMethods - names of generated methods.
+var generatedMethods = []string{"GetAttr", "SetAttr", "RoundFloatFields", "ToMap"}
+
+// generate - source of generated file for package in dir.
+// 'names': struct names, by default structs with directive comment.
+// 'output': generated file name, it is not loaded with package.
+func generate(dir string, names []string, output string) ([]byte, []string, error) {
+	pkg, files, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structs, err := selectStructs(pkg, files, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := &generator{
+		pkg:     pkg,
+		imports: make(map[string]string),
+		structs: make(map[*types.TypeName]bool, len(structs)),
+	}
+
+	for _, obj := range structs {
+		g.structs[obj] = true
+	}
+
+	for _, obj := range structs {
+		if err := g.genStruct(obj); err != nil {
+			return nil, g.warnings, fmt.Errorf("%s: %w", obj.Name(), err)
+		}
+	}
+
+	src, err := g.source()
+	if err != nil {
+		return nil, g.warnings, err
+	}
+
+	return src, g.warnings, nil
+}
+
+// loadPackage - parse and type-check package in dir without output file.
+func loadPackage(dir, output string) (*types.Package, []*ast.File, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		fset  = token.NewFileSet()
+		files = make([]*ast.File, 0, len(bp.GoFiles))
+	)
+
+	for _, name := range bp.GoFiles {
+		// generated file check
+		if name == filepath.Base(output) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check(bp.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkg, files, nil
+}
+
+// selectStructs - package types by names or with directive comment in source order.
+func selectStructs(pkg *types.Package, files []*ast.File, names []string) ([]*types.TypeName, error) {
+	var structs []*types.TypeName
+	// by names
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(strings.TrimSpace(name)).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s not found", name)
+		}
+
+		structs = append(structs, obj)
+	}
+
+	if len(names) > 0 {
+		return structs, nil
+	}
+	// by directive
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				// type doc or decl doc for single type
+				doc := ts.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+
+				if hasDirective(doc) {
+					structs = append(structs, pkg.Scope().Lookup(ts.Name.Name).(*types.TypeName))
+				}
+			}
+		}
+	}
+
+	if len(structs) == 0 {
+		return nil, fmt.Errorf("no types with %s comment in %s", directive, pkg.Name())
+	}
+
+	return structs, nil
+}
+
+// hasDirective - comment group has directive line.
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generator - builds generated methods of package structs.
+type generator struct {
+	pkg      *types.Package
+	buf      bytes.Buffer
+	imports  map[string]string        // import path to package name
+	structs  map[*types.TypeName]bool // generated structs
+	warnings []string
+	vars     map[string]int // loop var counters by name
+}
+
+// printf - write formatted code.
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// warnf - add warning for generated struct.
+func (g *generator) warnf(obj *types.TypeName, format string, args ...interface{}) {
+	g.warnings = append(g.warnings, obj.Name()+": "+fmt.Sprintf(format, args...))
+}
+
+// importName - package name in generated code, import is added.
+func (g *generator) importName(path, name string) string {
+	if imported, ok := g.imports[path]; ok {
+		return imported
+	}
+	// name collision check
+	used := make(map[string]bool, len(g.imports))
+	for _, imported := range g.imports {
+		used[imported] = true
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	g.imports[path] = unique
+
+	return unique
+}
+
+// attrs - attrs package name in generated code.
+func (g *generator) attrs() string {
+	return g.importName(attrsPath, "attrs")
+}
+
+// typeString - type in generated code, packages of named types are imported.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+
+		return g.importName(p.Path(), p.Name())
+	})
+}
+
+// accessible - type can be written in generated package.
+func (g *generator) accessible(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		if !t.Obj().Exported() && t.Obj().Pkg() != nil && t.Obj().Pkg() != g.pkg {
+			return false
+		}
+
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if !g.accessible(t.TypeArgs().At(i)) {
+				return false
+			}
+		}
+
+		return true
+	case *types.Pointer:
+		return g.accessible(t.Elem())
+	case *types.Slice:
+		return g.accessible(t.Elem())
+	case *types.Array:
+		return g.accessible(t.Elem())
+	case *types.Map:
+		return g.accessible(t.Key()) && g.accessible(t.Elem())
+	case *types.Chan:
+		return g.accessible(t.Elem())
+	default:
+		return true
+	}
+}
+
+// newVar - unique loop var name in generated method.
+func (g *generator) newVar(name string) string {
+	n := g.vars[name]
+	g.vars[name]++
+
+	if n == 0 {
+		return name
+	}
+
+	return name + strconv.Itoa(n)
+}
+
+// source - formatted generated file.
+func (g *generator) source() ([]byte, error) {
+	g.genHelpers()
+
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by attrsgen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name())
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	// standard packages first
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+
+		return paths[i] < paths[j]
+	})
+
+	out.WriteString("import (\n")
+
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) != isStd(path) {
+			out.WriteString("\n")
+		}
+
+		if name := g.imports[path]; name != filepath.Base(path) {
+			fmt.Fprintf(&out, "%s %q\n", name, path)
+			continue
+		}
+
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+// isStd - import path of standard package.
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// genHelpers - helper funcs of generated methods.
+func (g *generator) genHelpers() {
+	attrs, refl := g.attrs(), g.importName("reflect", "reflect")
+
+	g.printf("\n// attrsgenError - error of generated method like in attrs funcs.\n")
+	g.printf("func attrsgenError(op, name string, err error) error {\n")
+	g.printf("return &%s.AttrError{Op: op, Path: name, Err: err}\n}\n", attrs)
+
+	g.printf("\n// attrsgenTypeError - error of generated SetAttr for value of wrong type like in attrs.SetAttr.\n")
+	g.printf("func attrsgenTypeError[T any](name string, value interface{}) error {\n")
+	g.printf("expected := %s.TypeOf((*T)(nil)).Elem()\n", refl)
+	g.printf("// ptr field gets value by its elem\n")
+	g.printf("if expected.Kind() == %s.Ptr && value != nil {\nexpected = expected.Elem()\n}\n\n", refl)
+	g.printf("return &%s.AttrError{\nOp: \"SetAttr\",\nPath: name,\nErr: %s.ErrWrongFieldValueType,\n", attrs, attrs)
+	g.printf("Expected: expected,\nActual: %s.TypeOf(value),\n}\n}\n", refl)
+}
+
+// genStruct - generate methods of struct type.
+func (g *generator) genStruct(obj *types.TypeName) error {
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return fmt.Errorf("%w: generic or alias type", errUnsupported)
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return errors.New("not a struct")
+	}
+
+	fields, err := g.visibleFields(st)
+	if err != nil {
+		return err
+	}
+
+	recv := receiverName(obj.Name())
+
+	g.genGetAttr(obj, recv, fields)
+	g.genSetAttr(obj, recv, fields)
+
+	if err := g.genRound(obj, recv, st); err != nil {
+		if !errors.Is(err, errUnsupported) {
+			return err
+		}
+
+		g.warnf(obj, "RoundFloatFields is not generated, attrs.RoundStructFloatFields uses reflection: %v", err)
+	}
+
+	g.genToMap(obj, recv, st)
+	g.checkEmbedded(obj, st)
+
+	return nil
+}
+
+// checkEmbedded - warn about embedded fields with generated methods: they are promoted to struct.
+func (g *generator) checkEmbedded(obj *types.TypeName, st *types.Struct) {
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Embedded() {
+			continue
+		}
+
+		t := v.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+
+		named, ok := t.(*types.Named)
+		if !ok {
+			continue
+		}
+
+		mset := types.NewMethodSet(types.NewPointer(named))
+		for _, method := range generatedMethods {
+			if g.structs[named.Obj()] || mset.Lookup(named.Obj().Pkg(), method) != nil {
+				g.warnf(obj, "embedded %s has %s method, attrs funcs use reflection", v.Name(), method)
+				break
+			}
+		}
+	}
+}
+
+// receiverName - receiver name of generated methods, not used by vars of generated code.
+func receiverName(typeName string) string {
+	name := strings.ToLower(string([]rune(typeName)[:1]))
+	switch name {
+	case "i", "k", "m", "v", "_":
+		return "s"
+	default:
+		return name
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+// gentestDir - package with generated file, its tests check generated methods against reflection.
+const gentestDir = "../../attrs/internal/gentest"
+
+func TestGenerate(t *testing.T) {
+	runner.Run(t, "OK. Generated file is up to date", func(t provider.T) {
+		t.Epic("attrsgen")
+		t.Story("generate")
+		t.Description("Check func `generate` output equals generated file")
+
+		expected, err := os.ReadFile(filepath.Join(gentestDir, defaultOutput))
+		t.Require().NoError(err)
+
+		src, warnings, err := generate(gentestDir, nil, defaultOutput)
+		t.Require().NoError(err)
+		t.Assert().Equal(string(expected), string(src), "run go generate ./attrs/...")
+		t.Assert().Equal([]string{
+			"Event: RoundFloatFields is not generated, attrs.RoundStructFloatFields uses reflection: " +
+				"field Payload: not supported: interface type interface{}",
+			"Node: RoundFloatFields is not generated, attrs.RoundStructFloatFields uses reflection: " +
+				"field Next: not supported: recursive type Node",
+		}, warnings)
+	})
+
+	runner.Run(t, "OK. Types by names", func(t provider.T) {
+		t.Epic("attrsgen")
+		t.Story("generate")
+		t.Description("Check func `generate` with type names")
+
+		src, warnings, err := generate(gentestDir, []string{"Item", " Base"}, defaultOutput)
+		t.Require().NoError(err)
+		t.Assert().Empty(warnings)
+		t.Assert().Contains(string(src), "func (s *Item) SetAttr(name string, value interface{}) error {")
+		t.Assert().Contains(string(src), "func (b Base) ToMap() (map[string]interface{}, error) {")
+		t.Assert().NotContains(string(src), "Order")
+	})
+
+	runner.Run(t, "OK. Precision param check of py mode", func(t provider.T) {
+		t.Epic("attrsgen")
+		t.Story("generate")
+		t.Description("Check func `generate` checks precision param used by py mode")
+
+		src, warnings, err := generate("testdata/py", nil, defaultOutput)
+		t.Require().NoError(err)
+		t.Assert().Empty(warnings)
+		t.Assert().Contains(string(src), "if precision < 0 || precision > 13 {\n\t\treturn fmt.Errorf(")
+		t.Assert().Contains(string(src), `"%w: round precision %d of mode \"py\" not in 0..13", attrs.ErrInvalidTag, precision)`)
+		t.Assert().Contains(string(src), "p.Fixed = rmath.RoundPy(p.Fixed, 2)")
+	})
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		dir         string
+		names       []string
+		expectedErr string
+		testName    string
+	}{
+		{
+			dir:         "testdata/invalid",
+			expectedErr: `Price: field Value: invalid struct tag: round precision "two"`,
+			testName:    "ERR. Invalid round tag",
+		},
+		{
+			dir:         "testdata/invalid",
+			names:       []string{"Kind"},
+			expectedErr: "Kind: not a struct",
+			testName:    "ERR. Not a struct",
+		},
+		{
+			dir:         "testdata/invalid",
+			names:       []string{"Unknown"},
+			expectedErr: "type Unknown not found",
+			testName:    "ERR. Type not found",
+		},
+		{
+			dir:         "testdata/none",
+			expectedErr: "no types with //attrs:generate comment in none",
+			testName:    "ERR. No types",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrsgen")
+			t.Story("generate")
+			t.Description("Check func `generate` errors")
+			t.WithParameters(
+				allure.NewParameter("dir", testCase.dir),
+				allure.NewParameter("names", testCase.names),
+			)
+
+			_, _, err := generate(testCase.dir, testCase.names, defaultOutput)
+			t.Assert().EqualError(err, testCase.expectedErr)
+		})
+	}
+}
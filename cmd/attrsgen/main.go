@@ -0,0 +1,65 @@
+/*
+ * Author: ruauka
+ *
+ * License: MIT (See License file for full text).
+ */
+
+// Attrsgen - generates reflection-free GetAttr, SetAttr, RoundFloatFields and ToMap methods of structs.
+// Funcs of attrs package use generated methods instead of reflection, see attrs.AttrGetter,
+// attrs.AttrSetter, attrs.FloatRounder and attrs.Mapper.
+//
+// Structs are selected by `//attrs:generate` comment or by -type flag. Usage in package of structs:
+//
+//	//go:generate go run github.com/ruauka/tools-go/cmd/attrsgen
+//
+// Flags:
+//
+//	-type    comma-separated struct names, by default structs with `//attrs:generate` comment
+//	-output  output file name in package dir, attrs_gen.go by default
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultOutput - name of generated file.
+const defaultOutput = "attrs_gen.go"
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("attrsgen: ")
+
+	var (
+		typeNames = flag.String("type", "", "comma-separated struct names, by default structs with "+directive)
+		output    = flag.String("output", defaultOutput, "output file name in package dir")
+	)
+
+	flag.Parse()
+	// package dir
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	src, warnings, err := generate(dir, names, *output)
+	for _, warning := range warnings {
+		log.Print(warning)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// roundTagKey - struct tag key of per-field rounding, see attrs.RoundStructFloatFields.
+const roundTagKey = "round"
+
+// maxPyPrecision - max precision of rmath.RoundPy, it formats floats by a table of precisions.
+const maxPyPrecision = 13
+
+// roundFuncs - rmath func by round mode.
+var roundFuncs = map[string]string{
+	"half_up":   "Round",
+	"half_even": "RoundHalfEven",
+	"ceil":      "RoundUp",
+	"floor":     "RoundDown",
+	"trunc":     "RoundTrunc",
+	"py":        "RoundPy",
+}
+
+// roundModes - attrs.RoundMode constant by round mode.
+var roundModes = map[string]string{
+	"half_up":   "RoundHalfUp",
+	"half_even": "RoundHalfEven",
+	"ceil":      "RoundCeil",
+	"floor":     "RoundFloor",
+	"trunc":     "RoundTrunc",
+	"py":        "RoundPython",
+}
+
+// roundSpec - precision and mode expressions of field rounding.
+type roundSpec struct {
+	precision string // precision param or const
+	mode      string // round mode, empty for default half_up
+}
+
+// parseRoundTag - rounding of field by tag like attrs.RoundStructFloatFields, false for "-".
+func parseRoundTag(tag string, parent roundSpec) (roundSpec, bool, error) {
+	value, ok := reflect.StructTag(tag).Lookup(roundTagKey)
+	if !ok {
+		return parent, true, nil
+	}
+
+	if value == "-" {
+		return parent, false, nil
+	}
+
+	spec := parent
+	precision, mode, _ := strings.Cut(value, ",")
+
+	if precision = strings.TrimSpace(precision); precision != "" {
+		p, err := strconv.Atoi(precision)
+		if err != nil {
+			return spec, false, fmt.Errorf("invalid struct tag: round precision %q", precision)
+		}
+		spec.precision = strconv.Itoa(p)
+	}
+
+	if mode = strings.TrimSpace(mode); mode != "" {
+		if _, ok := roundFuncs[mode]; !ok {
+			return spec, false, fmt.Errorf("invalid struct tag: round mode %q", mode)
+		}
+		spec.mode = mode
+	}
+	// const precision of py mode check, param precision is checked by generated code
+	if p, err := strconv.Atoi(spec.precision); err == nil && spec.mode == "py" && (p < 0 || p > maxPyPrecision) {
+		return spec, false, fmt.Errorf("invalid struct tag: round precision %d of mode \"py\" not in 0..%d",
+			p, maxPyPrecision)
+	}
+
+	return spec, true, nil
+}
+
+// genRound - generate RoundFloatFields like attrs.RoundStructFloatFields: floats are rounded in place,
+// nested structs are rounded by attrs.RoundStructFloatFields.
+func (g *generator) genRound(obj *types.TypeName, recv string, st *types.Struct) error {
+	named := obj.Type().(*types.Named)
+	// rounded fields
+	var (
+		fields []*types.Var
+		specs  []roundSpec
+		stack  = map[*types.Named]bool{named: true}
+	)
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+
+		spec, ok, err := parseRoundTag(st.Tag(i), roundSpec{precision: "precision"})
+		if err != nil {
+			return fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		floats, err := g.hasFloats(v.Type(), stack, false)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+
+		if floats {
+			fields, specs = append(fields, v), append(specs, spec)
+		}
+	}
+
+	g.vars = make(map[string]int)
+	g.printf("\n// RoundFloatFields - round float fields without reflection, used by attrs.RoundStructFloatFields.\n")
+	g.printf("func (%s *%s) RoundFloatFields(precision int) error {\n", recv, obj.Name())
+	g.genPyPrecisionCheck(specs)
+
+	for i, v := range fields {
+		g.genRoundValue(recv+"."+v.Name(), v.Type(), specs[i])
+	}
+
+	if len(fields) > 0 {
+		g.printf("\n")
+	}
+
+	g.printf("return nil\n}\n")
+
+	return nil
+}
+
+// genPyPrecisionCheck - generate precision param check if it is used by py mode, rmath.RoundPy
+// supports 0..maxPyPrecision only.
+func (g *generator) genPyPrecisionCheck(specs []roundSpec) {
+	for _, spec := range specs {
+		if spec.mode != "py" || spec.precision != "precision" {
+			continue
+		}
+
+		format := fmt.Sprintf(`%%w: round precision %%d of mode "py" not in 0..%d`, maxPyPrecision)
+
+		g.printf("if precision < 0 || precision > %d {\n", maxPyPrecision)
+		g.printf("return %s.Errorf(%q, %s.ErrInvalidTag, precision)\n}\n\n", g.importName("fmt", "fmt"), format, g.attrs())
+
+		return
+	}
+}
+
+// hasFloats - type t has floats to round. Types through interfaces and recursive types are not supported,
+// except nested structs which are rounded by attrs funcs.
+func (g *generator) hasFloats(t types.Type, stack map[*types.Named]bool, nested bool) (bool, error) {
+	if named, ok := t.(*types.Named); ok {
+		if stack[named] {
+			return false, fmt.Errorf("%w: recursive type %s", errUnsupported, named.Obj().Name())
+		}
+
+		stack[named] = true
+		defer delete(stack, named)
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&types.IsFloat != 0, nil
+	case *types.Interface:
+		if !nested {
+			return false, fmt.Errorf("%w: interface type %s", errUnsupported, types.TypeString(t, types.RelativeTo(g.pkg)))
+		}
+
+		return true, nil
+	case *types.Pointer:
+		return g.hasFloats(u.Elem(), stack, nested)
+	case *types.Slice:
+		return g.hasFloats(u.Elem(), stack, nested)
+	case *types.Array:
+		return g.hasFloats(u.Elem(), stack, nested)
+	case *types.Map:
+		return g.hasFloats(u.Elem(), stack, nested)
+	case *types.Struct:
+		return g.structHasFloats(u, stack)
+	default:
+		return false, nil
+	}
+}
+
+// structHasFloats - nested struct has exported fields with floats to round.
+func (g *generator) structHasFloats(st *types.Struct, stack map[*types.Named]bool) (bool, error) {
+	var floats bool
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+
+		_, ok, err := parseRoundTag(st.Tag(i), roundSpec{})
+		if err != nil {
+			return false, fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		has, err := g.hasFloats(v.Type(), stack, true)
+		if err != nil {
+			return false, err
+		}
+
+		floats = floats || has
+	}
+
+	return floats, nil
+}
+
+// genRoundValue - round value expression of type t with floats.
+func (g *generator) genRoundValue(expr string, t types.Type, spec roundSpec) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		g.printf("%s = %s\n", expr, g.roundExpr(expr, t, spec))
+	case *types.Pointer:
+		if _, ok := u.Elem().Underlying().(*types.Struct); ok {
+			g.printf("if %s != nil {\n", expr)
+			g.genRoundStruct(expr, spec)
+			g.printf("}\n")
+
+			return
+		}
+
+		elem := "*" + expr
+		switch u.Elem().Underlying().(type) {
+		case *types.Slice, *types.Array, *types.Map:
+			elem = "(*" + expr + ")"
+		}
+
+		g.printf("if %s != nil {\n", expr)
+		g.genRoundValue(elem, u.Elem(), spec)
+		g.printf("}\n")
+	case *types.Struct:
+		g.genRoundStruct("&"+expr, spec)
+	case *types.Slice:
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, expr)
+		g.genRoundValue(expr+"["+i+"]", u.Elem(), spec)
+		g.printf("}\n")
+	case *types.Array:
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, expr)
+		g.genRoundValue(expr+"["+i+"]", u.Elem(), spec)
+		g.printf("}\n")
+	case *types.Map:
+		g.genRoundMap(expr, u, spec)
+	}
+}
+
+// genRoundMap - round map values, values are not addressable: round a copy and put it back.
+func (g *generator) genRoundMap(expr string, t *types.Map, spec roundSpec) {
+	k, v := g.newVar("k"), g.newVar("v")
+	// ptr values are rounded in place
+	if _, ok := t.Elem().Underlying().(*types.Pointer); ok {
+		g.printf("for _, %s := range %s {\n", v, expr)
+		g.genRoundValue(v, t.Elem(), spec)
+		g.printf("}\n")
+
+		return
+	}
+
+	g.printf("for %s, %s := range %s {\n", k, v, expr)
+	g.genRoundValue(v, t.Elem(), spec)
+	g.printf("%s[%s] = %s\n}\n", expr, k, v)
+}
+
+// genRoundStruct - round nested struct ptr expression by attrs.RoundStructFloatFields.
+func (g *generator) genRoundStruct(ptr string, spec roundSpec) {
+	attrs := g.attrs()
+
+	var opts string
+	if spec.mode != "" && spec.mode != "half_up" {
+		opts = fmt.Sprintf(", %s.WithRoundMode(%s.%s)", attrs, attrs, roundModes[spec.mode])
+	}
+
+	g.printf("if err := %s.RoundStructFloatFields(%s, %s%s); err != nil {\n", attrs, ptr, spec.precision, opts)
+	g.printf("return err\n}\n")
+}
+
+// roundExpr - rounded float expression of type t.
+func (g *generator) roundExpr(expr string, t types.Type, spec roundSpec) string {
+	mode := spec.mode
+	if mode == "" {
+		mode = "half_up"
+	}
+
+	round := g.importName(rmathPath, "rmath") + "." + roundFuncs[mode]
+	// float64 check
+	if types.Identical(t, types.Typ[types.Float64]) {
+		return fmt.Sprintf("%s(%s, %s)", round, expr, spec.precision)
+	}
+
+	return fmt.Sprintf("%s(%s(float64(%s), %s))", g.typeString(t), round, expr, spec.precision)
+}
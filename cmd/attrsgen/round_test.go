@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+)
+
+func TestParseRoundTag(t *testing.T) {
+	parent := roundSpec{precision: "precision"}
+
+	testCases := []struct {
+		tag         string
+		expected    roundSpec
+		expectedOk  bool
+		expectedErr bool
+		testName    string
+	}{
+		{tag: ``, expected: parent, expectedOk: true, testName: "OK. No tag"},
+		{tag: `round:"2"`, expected: roundSpec{precision: "2"}, expectedOk: true, testName: "OK. Precision"},
+		{
+			tag:        `round:" 3 , half_even"`,
+			expected:   roundSpec{precision: "3", mode: "half_even"},
+			expectedOk: true,
+			testName:   "OK. Precision and mode",
+		},
+		{tag: `round:"-"`, expected: parent, testName: "OK. Skipped"},
+		{tag: `round:",up"`, expectedErr: true, testName: "ERR. Invalid mode"},
+		{
+			tag:        `round:",py"`,
+			expected:   roundSpec{precision: "precision", mode: "py"},
+			expectedOk: true,
+			testName:   "OK. Param precision of py mode",
+		},
+		{tag: `round:"-2,py"`, expectedErr: true, testName: "ERR. Negative precision of py mode"},
+		{tag: `round:"14,py"`, expectedErr: true, testName: "ERR. Precision of py mode out of range"},
+	}
+
+	for _, testCase := range testCases {
+		runner.Run(t, testCase.testName, func(t provider.T) {
+			// allure id
+			t.AllureID(fmt.Sprintf("%s_%s", t.Name(), testCase.testName))
+
+			// allure report info
+			t.Epic("attrsgen")
+			t.Story("parseRoundTag")
+			t.Description("Check func `parseRoundTag`")
+			t.WithParameters(allure.NewParameter("tag", testCase.tag))
+
+			spec, ok, err := parseRoundTag(testCase.tag, parent)
+			if testCase.expectedErr {
+				t.Assert().Error(err)
+				return
+			}
+
+			t.Require().NoError(err)
+			t.Assert().Equal(testCase.expectedOk, ok)
+			t.Assert().Equal(testCase.expected, spec)
+		})
+	}
+}
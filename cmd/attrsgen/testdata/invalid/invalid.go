@@ -0,0 +1,11 @@
+package invalid
+
+// Kind - not a struct.
+type Kind int
+
+// Price - struct with invalid round tag.
+//
+//attrs:generate
+type Price struct {
+	Value float64 `round:"two"`
+}
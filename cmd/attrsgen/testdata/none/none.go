@@ -0,0 +1,6 @@
+package none
+
+// Price - struct without generate comment.
+type Price struct {
+	Value float64
+}
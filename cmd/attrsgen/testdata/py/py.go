@@ -0,0 +1,9 @@
+package py
+
+// Price - py rounding by precision param.
+//
+//attrs:generate
+type Price struct {
+	Value float64 `round:",py"`
+	Fixed float64 `round:"2,py"`
+}
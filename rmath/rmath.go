@@ -30,6 +30,21 @@ func RoundUp[T constraints.Float](value T, prec int) T {
 	return T(math.Ceil(float64(value)*(math.Pow10(prec))) / math.Pow10(prec))
 }
 
+// RoundDown returns down float64 | float32 with precision.
+func RoundDown[T constraints.Float](value T, prec int) T {
+	return T(math.Floor(float64(value)*(math.Pow10(prec))) / math.Pow10(prec))
+}
+
+// RoundTrunc returns float64 | float32 truncated toward zero with precision.
+func RoundTrunc[T constraints.Float](value T, prec int) T {
+	return T(math.Trunc(float64(value)*(math.Pow10(prec))) / math.Pow10(prec))
+}
+
+// RoundHalfEven returns float64 | float32 with precision, halves are rounded to even (banker's rounding).
+func RoundHalfEven[T constraints.Float](value T, prec int) T {
+	return T(math.RoundToEven(float64(value)*(math.Pow10(prec))) / math.Pow10(prec))
+}
+
 // RoundFloor returns floor float64 with rounding value.
 func RoundFloor(value float64, rounding float64) float64 {
 	return math.Floor(value/rounding) * rounding
@@ -69,3 +69,44 @@ func ExampleRoundUp() {
 	// 0.124
 	// float64
 }
+
+func TestRoundDown(t *testing.T) {
+	t.Run("OK float32", func(t *testing.T) {
+		result := RoundDown(float32(0.987654321), 3)
+		require.Equal(t, float32(0.987), result)
+	})
+	t.Run("OK float64", func(t *testing.T) {
+		result := RoundDown(-0.123456789, 3)
+		require.Equal(t, -0.124, result)
+	})
+}
+
+func TestRoundTrunc(t *testing.T) {
+	t.Run("OK float32", func(t *testing.T) {
+		result := RoundTrunc(float32(0.987654321), 3)
+		require.Equal(t, float32(0.987), result)
+	})
+	t.Run("OK float64", func(t *testing.T) {
+		result := RoundTrunc(-0.123456789, 3)
+		require.Equal(t, -0.123, result)
+	})
+}
+
+func TestRoundHalfEven(t *testing.T) {
+	t.Run("OK float32", func(t *testing.T) {
+		result := RoundHalfEven(float32(2.5), 0)
+		require.Equal(t, float32(2), result)
+	})
+	t.Run("OK float64", func(t *testing.T) {
+		result := RoundHalfEven(3.5, 0)
+		require.Equal(t, 4.0, result)
+	})
+}
+
+func ExampleRoundHalfEven() {
+	fmt.Println(RoundHalfEven(0.5, 0), RoundHalfEven(1.5, 0), RoundHalfEven(2.5, 0))
+	fmt.Println(Round(0.5, 0), Round(1.5, 0), Round(2.5, 0))
+
+	// Output: 0 2 2
+	// 1 2 3
+}